@@ -1,8 +1,6 @@
 package controllers
 
 import (
-	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"time"
@@ -11,7 +9,7 @@ import (
 	"github.com/hotbrainy/go-betting/backend/db/initializers"
 	format_errors "github.com/hotbrainy/go-betting/backend/internal/format-errors"
 	"github.com/hotbrainy/go-betting/backend/internal/models"
-	"github.com/hotbrainy/go-betting/backend/internal/redis"
+	"github.com/hotbrainy/go-betting/backend/internal/services"
 )
 
 // GetAlerts returns all alerts, optionally filtered by read status with pagination
@@ -170,23 +168,5 @@ func DeleteAlert(c *gin.Context) {
 
 // CreateAlert is a helper function to create alerts (used internally)
 func CreateAlert(alertType, title, message string, entityID uint, redirectURL string) (*models.Alert, error) {
-	alert := models.Alert{
-		Type:        alertType,
-		Title:       title,
-		Message:     message,
-		EntityID:    entityID,
-		IsRead:      false,
-		RedirectURL: redirectURL,
-	}
-
-	if err := initializers.DB.Create(&alert).Error; err != nil {
-		return nil, err
-	}
-
-	// Publish alert to Redis for real-time updates
-	rdb := redis.Client
-	alertJSON, _ := json.Marshal(alert)
-	rdb.Publish(context.Background(), "alerts:admin", alertJSON)
-
-	return &alert, nil
+	return services.CreateAlert(initializers.DB, alertType, title, message, entityID, redirectURL)
 }
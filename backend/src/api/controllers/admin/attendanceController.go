@@ -0,0 +1,280 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/hotbrainy/go-betting/backend/db/initializers"
+	format_errors "github.com/hotbrainy/go-betting/backend/internal/format-errors"
+	"github.com/hotbrainy/go-betting/backend/internal/models"
+	"github.com/hotbrainy/go-betting/backend/internal/services"
+	"github.com/hotbrainy/go-betting/backend/internal/validations"
+	"gorm.io/gorm"
+)
+
+// GetAttendanceConfig returns the global attendance config
+func GetAttendanceConfig(c *gin.Context) {
+	var config models.AttendanceConfig
+
+	if err := initializers.DB.First(&config).Error; err != nil {
+		// If no config exists, return the defaults
+		config = models.AttendanceConfig{
+			IsActive:     true,
+			DayStartHour: 0,
+			MaxPerDevice: 1,
+			MaxPerIP:     3,
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    config,
+	})
+}
+
+// UpdateAttendanceConfig creates or updates the global attendance config
+func UpdateAttendanceConfig(c *gin.Context) {
+	var userInput struct {
+		IsActive     bool `json:"isActive"`
+		DayStartHour int  `json:"dayStartHour" binding:"min=0,max=23"`
+		MaxPerDevice int  `json:"maxPerDevice" binding:"min=0"`
+		MaxPerIP     int  `json:"maxPerIp" binding:"min=0"`
+	}
+
+	if err := c.ShouldBindJSON(&userInput); err != nil {
+		if errs, ok := err.(validator.ValidationErrors); ok {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{
+				"validations": validations.FormatValidationErrors(errs),
+			})
+			return
+		}
+		format_errors.BadRequestError(c, err)
+		return
+	}
+
+	var config models.AttendanceConfig
+	result := initializers.DB.First(&config)
+
+	config.IsActive = userInput.IsActive
+	config.DayStartHour = userInput.DayStartHour
+	config.MaxPerDevice = userInput.MaxPerDevice
+	config.MaxPerIP = userInput.MaxPerIP
+
+	if result.Error != nil {
+		result = initializers.DB.Create(&config)
+	} else {
+		// Save so that false / zero values are written as well
+		result = initializers.DB.Save(&config)
+	}
+
+	if err := result.Error; err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Attendance config updated successfully",
+		"data":    config,
+	})
+}
+
+// GetAttendanceRewards lists the streak reward settings
+func GetAttendanceRewards(c *gin.Context) {
+	var rewards []models.AttendanceReward
+
+	if err := initializers.DB.Order("streak_days ASC").Find(&rewards).Error; err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    rewards,
+	})
+}
+
+// UpsertAttendanceReward creates or updates the reward for a streak length
+func UpsertAttendanceReward(c *gin.Context) {
+	var userInput struct {
+		StreakDays int     `json:"streakDays" binding:"required,oneof=7 14 28"`
+		RewardType string  `json:"rewardType" binding:"required,oneof=point coupon"`
+		Amount     float64 `json:"amount" binding:"min=0"`
+		IsActive   bool    `json:"isActive"`
+
+		CouponCampaignID *uint `json:"couponCampaignId"`
+	}
+
+	if err := c.ShouldBindJSON(&userInput); err != nil {
+		if errs, ok := err.(validator.ValidationErrors); ok {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{
+				"validations": validations.FormatValidationErrors(errs),
+			})
+			return
+		}
+		format_errors.BadRequestError(c, err)
+		return
+	}
+
+	if userInput.RewardType == "coupon" {
+		var campaign models.CouponCampaign
+		if userInput.CouponCampaignID == nil || initializers.DB.First(&campaign, *userInput.CouponCampaignID).Error != nil {
+			format_errors.BadRequestError(c, fmt.Errorf("A valid couponCampaignId is required for coupon rewards"))
+			return
+		}
+		// Only enforced for active rewards, so an exhausted reward can still be switched off
+		if userInput.IsActive {
+			if err := services.CheckCouponCampaignIssuable(&campaign); err != nil {
+				format_errors.BadRequestError(c, fmt.Errorf("Coupon campaign cannot issue coupons: %v", err))
+				return
+			}
+		}
+	} else {
+		userInput.CouponCampaignID = nil
+	}
+
+	var reward models.AttendanceReward
+	result := initializers.DB.Where("streak_days = ?", userInput.StreakDays).First(&reward)
+
+	reward.StreakDays = userInput.StreakDays
+	reward.RewardType = userInput.RewardType
+	reward.Amount = userInput.Amount
+	reward.IsActive = userInput.IsActive
+	reward.CouponCampaignID = userInput.CouponCampaignID
+
+	if result.Error != nil {
+		result = initializers.DB.Create(&reward)
+	} else {
+		result = initializers.DB.Save(&reward)
+	}
+
+	if err := result.Error; err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Attendance reward saved successfully",
+		"data":    reward,
+	})
+}
+
+// DeleteAttendanceReward deletes a streak reward
+func DeleteAttendanceReward(c *gin.Context) {
+	id := c.Param("id")
+
+	var reward models.AttendanceReward
+	if err := initializers.DB.First(&reward, id).Error; err != nil {
+		format_errors.NotFound(c, err)
+		return
+	}
+
+	// Hard delete so the streak length can be configured again
+	if err := initializers.DB.Unscoped().Delete(&reward).Error; err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Attendance reward deleted successfully",
+	})
+}
+
+// GetAttendanceReport returns daily check-in totals and the matching check-in rows for a date range
+func GetAttendanceReport(c *gin.Context) {
+	dateFrom := c.DefaultQuery("dateFrom", time.Now().AddDate(0, 0, -6).Format("2006-01-02"))
+	dateTo := c.DefaultQuery("dateTo", time.Now().Format("2006-01-02"))
+	search := c.Query("search")
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if page < 1 {
+		page = 1
+	}
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("pageSize", "20"))
+	if pageSize < 1 {
+		pageSize = 20
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	if _, err := time.Parse("2006-01-02", dateFrom); err != nil {
+		format_errors.BadRequestError(c, fmt.Errorf("Invalid dateFrom, expected YYYY-MM-DD"))
+		return
+	}
+	if _, err := time.Parse("2006-01-02", dateTo); err != nil {
+		format_errors.BadRequestError(c, fmt.Errorf("Invalid dateTo, expected YYYY-MM-DD"))
+		return
+	}
+
+	// Per-day summary
+	type dailySummary struct {
+		AttendanceDate string  `json:"attendanceDate"`
+		Users          int64   `json:"users"`
+		Rewarded       int64   `json:"rewarded"`
+		PointsPaid     float64 `json:"pointsPaid"`
+		CouponsPaid    float64 `json:"couponsPaid"`
+		Duplicates     int64   `json:"duplicates"`
+	}
+	var summary []dailySummary
+	if err := initializers.DB.Model(&models.Attendance{}).
+		Select(`attendance_date,
+			COUNT(*) AS users,
+			COUNT(*) FILTER (WHERE reward_amount > 0) AS rewarded,
+			COALESCE(SUM(reward_amount) FILTER (WHERE reward_type = 'point'), 0) AS points_paid,
+			COALESCE(SUM(reward_amount) FILTER (WHERE reward_type = 'coupon'), 0) AS coupons_paid,
+			COALESCE(SUM(request_count - 1), 0) AS duplicates`).
+		Where("attendance_date BETWEEN ? AND ?", dateFrom, dateTo).
+		Group("attendance_date").
+		Order("attendance_date DESC").
+		Scan(&summary).Error; err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	// Detail rows
+	query := initializers.DB.Model(&models.Attendance{}).
+		Where("attendances.attendance_date BETWEEN ? AND ?", dateFrom, dateTo)
+
+	if search != "" {
+		searchPattern := "%" + search + "%"
+		query = query.Joins("JOIN users ON users.id = attendances.user_id").
+			Where("users.userid LIKE ? OR attendances.ip LIKE ? OR attendances.device_id LIKE ?",
+				searchPattern, searchPattern, searchPattern)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	var attendances []models.Attendance
+	if err := query.
+		Preload("User", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id, userid, name")
+		}).
+		Order("attendances.created_at DESC").
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Find(&attendances).Error; err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":  true,
+		"summary":  summary,
+		"data":     attendances,
+		"total":    total,
+		"page":     page,
+		"pageSize": pageSize,
+		"pages":    (int(total) + pageSize - 1) / pageSize,
+	})
+}
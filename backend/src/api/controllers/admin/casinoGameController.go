@@ -0,0 +1,207 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/hotbrainy/go-betting/backend/db/initializers"
+	"github.com/hotbrainy/go-betting/backend/internal/casino"
+	format_errors "github.com/hotbrainy/go-betting/backend/internal/format-errors"
+	"github.com/hotbrainy/go-betting/backend/internal/models"
+	"github.com/hotbrainy/go-betting/backend/internal/services"
+	"github.com/hotbrainy/go-betting/backend/internal/validations"
+)
+
+// AdminGetCasinoGames lists the casino catalog including hidden and delisted games
+func AdminGetCasinoGames(c *gin.Context) {
+	query := services.CasinoGameQuery(initializers.DB, services.CasinoGameFilter{
+		Vendor:       c.Query("vendor"),
+		Type:         c.Query("type"),
+		Tag:          c.Query("tag"),
+		Search:       strings.TrimSpace(c.Query("q")),
+		FeaturedOnly: c.Query("featured") == "true",
+	})
+	if enabled := c.Query("enabled"); enabled != "" {
+		query = query.Where("enabled = ?", enabled == "true")
+	}
+	if available := c.Query("available"); available != "" {
+		query = query.Where("available = ?", available == "true")
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if page < 1 {
+		page = 1
+	}
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("pageSize", "50"))
+	if pageSize < 1 || pageSize > 200 {
+		pageSize = 50
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	var games []models.CasinoGame
+	if err := query.Order(services.CasinoGameLobbyOrder).
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Find(&games).Error; err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":  true,
+		"data":     games,
+		"total":    total,
+		"page":     page,
+		"pageSize": pageSize,
+	})
+}
+
+// AdminUpdateCasinoGame hides, features, orders or tags a catalog game
+func AdminUpdateCasinoGame(c *gin.Context) {
+	var userInput struct {
+		Enabled   *bool     `json:"enabled"`
+		Featured  *bool     `json:"featured"`
+		SortOrder *int      `json:"sortOrder"`
+		Tags      *[]string `json:"tags" binding:"omitempty,max=10,dive,max=30"`
+	}
+
+	if err := c.ShouldBindJSON(&userInput); err != nil {
+		if errs, ok := err.(validator.ValidationErrors); ok {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{
+				"validations": validations.FormatValidationErrors(errs),
+			})
+			return
+		}
+		format_errors.BadRequestError(c, err)
+		return
+	}
+
+	var game models.CasinoGame
+	if err := initializers.DB.First(&game, c.Param("id")).Error; err != nil {
+		format_errors.NotFound(c, err)
+		return
+	}
+
+	updates := map[string]interface{}{}
+	if userInput.Enabled != nil {
+		updates["enabled"] = *userInput.Enabled
+	}
+	if userInput.Featured != nil {
+		updates["featured"] = *userInput.Featured
+	}
+	if userInput.SortOrder != nil {
+		updates["sort_order"] = *userInput.SortOrder
+	}
+	if userInput.Tags != nil {
+		updates["tags"] = services.NormalizeCasinoGameTags(*userInput.Tags)
+	}
+	if len(updates) > 0 {
+		if err := initializers.DB.Model(&game).Updates(updates).Error; err != nil {
+			format_errors.InternalServerError(c, err)
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Casino game updated",
+		"data":    game,
+	})
+}
+
+// AdminReorderCasinoGames sets the sort order of the given games to their position in the list
+func AdminReorderCasinoGames(c *gin.Context) {
+	var userInput struct {
+		IDs []uint `json:"ids" binding:"required,min=1,max=500"`
+	}
+
+	if err := c.ShouldBindJSON(&userInput); err != nil {
+		if errs, ok := err.(validator.ValidationErrors); ok {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{
+				"validations": validations.FormatValidationErrors(errs),
+			})
+			return
+		}
+		format_errors.BadRequestError(c, err)
+		return
+	}
+
+	tx := initializers.DB.Begin()
+	for i, id := range userInput.IDs {
+		if err := tx.Model(&models.CasinoGame{}).Where("id = ?", id).
+			Update("sort_order", i+1).Error; err != nil {
+			tx.Rollback()
+			format_errors.InternalServerError(c, err)
+			return
+		}
+	}
+	if err := tx.Commit().Error; err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Casino games reordered",
+	})
+}
+
+// AdminSyncCasinoGames refreshes the catalog from the providers now, for one vendor or for all
+func AdminSyncCasinoGames(c *gin.Context) {
+	var userInput struct {
+		Vendor string `json:"vendor" binding:"max=100"`
+		Type   string `json:"type" binding:"max=50"`
+	}
+
+	if err := c.ShouldBindJSON(&userInput); err != nil {
+		if errs, ok := err.(validator.ValidationErrors); ok {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{
+				"validations": validations.FormatValidationErrors(errs),
+			})
+			return
+		}
+		format_errors.BadRequestError(c, err)
+		return
+	}
+
+	ctx := context.Background()
+	var synced int
+	var err error
+	if userInput.Vendor == "" {
+		synced, err = services.SyncCasinoCatalog(ctx, initializers.DB)
+	} else {
+		var provider casino.Provider
+		provider, err = casino.ForVendor(initializers.DB, userInput.Vendor)
+		if err == nil {
+			synced, err = services.SyncCasinoGames(ctx, initializers.DB, provider, userInput.Vendor, userInput.Type)
+		}
+	}
+	if err != nil {
+		if errors.Is(err, casino.ErrVendorDisabled) {
+			format_errors.BadRequestError(c, err)
+			return
+		}
+		c.JSON(http.StatusBadGateway, gin.H{
+			"success": false,
+			"error":   err.Error(),
+			"synced":  synced,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Casino games synced",
+		"synced":  synced,
+	})
+}
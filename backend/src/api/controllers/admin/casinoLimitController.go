@@ -0,0 +1,139 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/hotbrainy/go-betting/backend/db/initializers"
+	format_errors "github.com/hotbrainy/go-betting/backend/internal/format-errors"
+	"github.com/hotbrainy/go-betting/backend/internal/models"
+	"github.com/hotbrainy/go-betting/backend/internal/services"
+	"github.com/hotbrainy/go-betting/backend/internal/validations"
+)
+
+// AdminGetCasinoLimitBreaches lists the casino limit breaches, newest first
+func AdminGetCasinoLimitBreaches(c *gin.Context) {
+	query := initializers.DB.Model(&models.CasinoLimitBreach{})
+	if userID := c.Query("userId"); userID != "" {
+		query = query.Where("user_id = ?", userID)
+	}
+	if kind := c.Query("kind"); kind != "" {
+		query = query.Where("kind = ?", kind)
+	}
+	if day := c.Query("day"); day != "" {
+		query = query.Where("day = ?", day)
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if page < 1 {
+		page = 1
+	}
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("pageSize", "50"))
+	if pageSize < 1 || pageSize > 200 {
+		pageSize = 50
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	var breaches []models.CasinoLimitBreach
+	if err := query.Preload("User").
+		Order("updated_at DESC").
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Find(&breaches).Error; err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":  true,
+		"data":     breaches,
+		"total":    total,
+		"page":     page,
+		"pageSize": pageSize,
+	})
+}
+
+// AdminGetUserCasinoLimits returns the user's own casino limits, the limits in effect with
+// their level's filling in, and today's usage
+func AdminGetUserCasinoLimits(c *gin.Context) {
+	var user models.User
+	if err := initializers.DB.First(&user, c.Param("id")).Error; err != nil {
+		format_errors.NotFound(c, err)
+		return
+	}
+
+	effective, err := services.CasinoLimitsOf(initializers.DB, user.ID)
+	if err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+	turnover, loss, err := services.CasinoDailyUsage(initializers.DB, user.ID, time.Now())
+	if err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": gin.H{
+			"user": services.CasinoLimits{
+				MaxBet:        user.CasinoMaxBet,
+				DailyTurnover: user.CasinoDailyTurnoverLimit,
+				DailyLoss:     user.CasinoDailyLossLimit,
+			},
+			"effective": effective,
+			"today": gin.H{
+				"turnover": turnover,
+				"loss":     loss,
+			},
+		},
+	})
+}
+
+// AdminUpdateUserCasinoLimits sets the user's own casino limits, 0 falls back to the level's
+func AdminUpdateUserCasinoLimits(c *gin.Context) {
+	var userInput struct {
+		MaxBet        float64 `json:"maxBet" binding:"min=0"`
+		DailyTurnover float64 `json:"dailyTurnover" binding:"min=0"`
+		DailyLoss     float64 `json:"dailyLoss" binding:"min=0"`
+	}
+
+	if err := c.ShouldBindJSON(&userInput); err != nil {
+		if errs, ok := err.(validator.ValidationErrors); ok {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{
+				"validations": validations.FormatValidationErrors(errs),
+			})
+			return
+		}
+		format_errors.BadRequestError(c, err)
+		return
+	}
+
+	var user models.User
+	if err := initializers.DB.First(&user, c.Param("id")).Error; err != nil {
+		format_errors.NotFound(c, err)
+		return
+	}
+
+	if err := initializers.DB.Model(&user).Updates(map[string]interface{}{
+		"casino_max_bet":              userInput.MaxBet,
+		"casino_daily_turnover_limit": userInput.DailyTurnover,
+		"casino_daily_loss_limit":     userInput.DailyLoss,
+	}).Error; err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Casino limits updated",
+	})
+}
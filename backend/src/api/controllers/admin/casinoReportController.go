@@ -0,0 +1,155 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hotbrainy/go-betting/backend/db/initializers"
+	format_errors "github.com/hotbrainy/go-betting/backend/internal/format-errors"
+	"github.com/hotbrainy/go-betting/backend/internal/models"
+	"github.com/hotbrainy/go-betting/backend/internal/services"
+	"gorm.io/gorm"
+)
+
+// casinoReportFilter reads the report filters of the query string
+func casinoReportFilter(c *gin.Context) services.CasinoReportFilter {
+	userID, _ := strconv.ParseUint(c.Query("userId"), 10, 64)
+	return services.CasinoReportFilter{
+		DateFrom: c.Query("dateFrom"),
+		DateTo:   c.Query("dateTo"),
+		Vendor:   c.Query("vendor"),
+		GameCode: c.Query("gameCode"),
+		GameType: c.Query("gameType"),
+		Day:      c.Query("day"),
+		UserID:   uint(userID),
+	}
+}
+
+// casinoReportPage reads page and pageSize of the query string
+func casinoReportPage(c *gin.Context) (int, int) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if page < 1 {
+		page = 1
+	}
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("pageSize", "50"))
+	if pageSize < 1 || pageSize > 200 {
+		pageSize = 50
+	}
+	return page, pageSize
+}
+
+// AdminGetCasinoGGR reports casino GGR (bets minus wins) by vendor, game or day
+func AdminGetCasinoGGR(c *gin.Context) {
+	rows, err := services.CasinoGGRReport(initializers.DB, casinoReportFilter(c), c.DefaultQuery("groupBy", "vendor"))
+	if err != nil {
+		if errors.Is(err, services.ErrCasinoReportGroup) {
+			format_errors.BadRequestError(c, err)
+			return
+		}
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	var totals services.CasinoGGRRow
+	for _, row := range rows {
+		totals.BetAmount += row.BetAmount
+		totals.WinAmount += row.WinAmount
+		totals.GGR += row.GGR
+		totals.BetCount += row.BetCount
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    rows,
+		"totals":  totals,
+	})
+}
+
+// AdminGetCasinoUserGGR drills a GGR report line down to the users who played it
+func AdminGetCasinoUserGGR(c *gin.Context) {
+	page, pageSize := casinoReportPage(c)
+
+	rows, total, err := services.CasinoUserGGR(initializers.DB, casinoReportFilter(c), (page-1)*pageSize, pageSize)
+	if err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":  true,
+		"data":     rows,
+		"total":    total,
+		"page":     page,
+		"pageSize": pageSize,
+	})
+}
+
+// AdminGetCasinoTopPlayers returns the users who won and lost the most in the casino
+func AdminGetCasinoTopPlayers(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+
+	winners, losers, err := services.CasinoTopPlayers(initializers.DB, casinoReportFilter(c), limit)
+	if err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": gin.H{
+			"winners": winners,
+			"losers":  losers,
+		},
+	})
+}
+
+// AdminSearchCasinoBets searches casino bets and wins by their game columns
+func AdminSearchCasinoBets(c *gin.Context) {
+	query := services.CasinoBetQuery(initializers.DB, casinoReportFilter(c))
+	if roundID := c.Query("roundId"); roundID != "" {
+		query = query.Where("casino_bets.round_id = ?", roundID)
+	}
+	if title := strings.TrimSpace(c.Query("gameTitle")); title != "" {
+		query = query.Where("casino_bets.game_title ILIKE ?", "%"+title+"%")
+	}
+	if betType := c.Query("type"); betType != "" {
+		query = query.Where("casino_bets.type = ?", betType)
+	}
+	if status := c.Query("status"); status != "" {
+		query = query.Where("casino_bets.status = ?", status)
+	}
+
+	page, pageSize := casinoReportPage(c)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	var bets []models.CasinoBet
+	if err := query.Preload("User", func(db *gorm.DB) *gorm.DB {
+		return db.Preload("Profile")
+	}).
+		Order("casino_bets.played_at DESC").
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Find(&bets).Error; err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":  true,
+		"data":     bets,
+		"total":    total,
+		"page":     page,
+		"pageSize": pageSize,
+	})
+}
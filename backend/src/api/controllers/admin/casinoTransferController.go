@@ -0,0 +1,115 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hotbrainy/go-betting/backend/db/initializers"
+	"github.com/hotbrainy/go-betting/backend/internal/casino"
+	format_errors "github.com/hotbrainy/go-betting/backend/internal/format-errors"
+	"github.com/hotbrainy/go-betting/backend/internal/models"
+	"github.com/hotbrainy/go-betting/backend/internal/services"
+)
+
+// AdminGetCasinoTransfers lists the balance transfers between profiles and casino accounts,
+// newest first
+func AdminGetCasinoTransfers(c *gin.Context) {
+	query := initializers.DB.Model(&models.CasinoTransfer{})
+	if status := c.Query("status"); status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if direction := c.Query("direction"); direction != "" {
+		query = query.Where("direction = ?", direction)
+	}
+	if userID := c.Query("userId"); userID != "" {
+		query = query.Where("user_id = ?", userID)
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if page < 1 {
+		page = 1
+	}
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("pageSize", "50"))
+	if pageSize < 1 || pageSize > 200 {
+		pageSize = 50
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	var transfers []models.CasinoTransfer
+	if err := query.Preload("User").
+		Order("id DESC").
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Find(&transfers).Error; err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":  true,
+		"data":     transfers,
+		"total":    total,
+		"page":     page,
+		"pageSize": pageSize,
+	})
+}
+
+// AdminResolveCasinoTransfer settles an unfinished transfer now instead of waiting for the
+// recovery worker. Admins settle a transfer in review by telling whether the provider applied
+// it, after checking the casino account: {"applied": true} commits it, false compensates it.
+func AdminResolveCasinoTransfer(c *gin.Context) {
+	var userInput struct {
+		Applied *bool `json:"applied"`
+	}
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&userInput); err != nil {
+			format_errors.BadRequestError(c, err)
+			return
+		}
+	}
+
+	var transfer models.CasinoTransfer
+	if err := initializers.DB.First(&transfer, c.Param("id")).Error; err != nil {
+		format_errors.NotFound(c, err)
+		return
+	}
+
+	if userInput.Applied != nil {
+		if err := services.SettleCasinoTransfer(initializers.DB, &transfer, *userInput.Applied); err != nil {
+			if errors.Is(err, services.ErrCasinoTransferSettled) {
+				format_errors.ConflictError(c, err)
+				return
+			}
+			format_errors.InternalServerError(c, err)
+			return
+		}
+	} else {
+		provider, err := casino.Get(transfer.Provider)
+		if err != nil {
+			format_errors.InternalServerError(c, err)
+			return
+		}
+
+		if err := services.ResolveCasinoTransfer(c.Request.Context(), initializers.DB, provider, &transfer); err != nil {
+			c.JSON(http.StatusBadGateway, gin.H{
+				"success": false,
+				"error":   err.Error(),
+				"data":    transfer,
+			})
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Casino transfer " + transfer.Status,
+		"data":    transfer,
+	})
+}
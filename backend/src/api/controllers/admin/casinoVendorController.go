@@ -0,0 +1,156 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/hotbrainy/go-betting/backend/db/initializers"
+	format_errors "github.com/hotbrainy/go-betting/backend/internal/format-errors"
+	"github.com/hotbrainy/go-betting/backend/internal/models"
+	"github.com/hotbrainy/go-betting/backend/internal/services"
+	"github.com/hotbrainy/go-betting/backend/internal/validations"
+)
+
+// casinoVendorInput is the full set of a vendor's controls, PUT replaces all of them
+type casinoVendorInput struct {
+	Vendor             string     `json:"vendor" binding:"required,max=100"`
+	Name               string     `json:"name" binding:"max=100"`
+	Category           string     `json:"category" binding:"omitempty,oneof=live slot holdem"`
+	Enabled            *bool      `json:"enabled"`
+	OrderNum           uint       `json:"orderNum"`
+	MaintenanceStart   *time.Time `json:"maintenanceStart"`
+	MaintenanceEnd     *time.Time `json:"maintenanceEnd"`
+	MaintenanceMessage string     `json:"maintenanceMessage" binding:"max=1000"`
+	MinLevel           int32      `json:"minLevel" binding:"min=0"`
+	DomainIDs          []uint     `json:"domainIds"`
+}
+
+// bindCasinoVendor reads the vendor controls of the request into vendor, answering the
+// request itself when they are invalid
+func bindCasinoVendor(c *gin.Context, vendor *models.CasinoVendor) bool {
+	var userInput casinoVendorInput
+	if err := c.ShouldBindJSON(&userInput); err != nil {
+		if errs, ok := err.(validator.ValidationErrors); ok {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{
+				"validations": validations.FormatValidationErrors(errs),
+			})
+			return false
+		}
+		format_errors.BadRequestError(c, err)
+		return false
+	}
+
+	if userInput.MaintenanceEnd != nil && userInput.MaintenanceStart == nil {
+		format_errors.BadRequestError(c, errors.New("maintenanceEnd requires maintenanceStart"))
+		return false
+	}
+	if userInput.MaintenanceEnd != nil && !userInput.MaintenanceEnd.After(*userInput.MaintenanceStart) {
+		format_errors.BadRequestError(c, errors.New("maintenanceEnd must be after maintenanceStart"))
+		return false
+	}
+
+	vendor.Vendor = services.CasinoVendorKey(userInput.Vendor)
+	vendor.Name = userInput.Name
+	vendor.Category = userInput.Category
+	vendor.Enabled = userInput.Enabled == nil || *userInput.Enabled
+	vendor.OrderNum = userInput.OrderNum
+	vendor.MaintenanceStart = userInput.MaintenanceStart
+	vendor.MaintenanceEnd = userInput.MaintenanceEnd
+	vendor.MaintenanceMessage = userInput.MaintenanceMessage
+	vendor.MinLevel = userInput.MinLevel
+	vendor.DomainIDs = models.UintArray(userInput.DomainIDs)
+	if vendor.DomainIDs == nil {
+		vendor.DomainIDs = models.UintArray{}
+	}
+	return true
+}
+
+// AdminGetCasinoVendors lists the vendor records
+func AdminGetCasinoVendors(c *gin.Context) {
+	var vendors []models.CasinoVendor
+	if err := initializers.DB.Order("order_num ASC, id ASC").Find(&vendors).Error; err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    vendors,
+	})
+}
+
+// AdminCreateCasinoVendor adds the controls of a vendor
+func AdminCreateCasinoVendor(c *gin.Context) {
+	var vendor models.CasinoVendor
+	if !bindCasinoVendor(c, &vendor) {
+		return
+	}
+
+	var existing int64
+	if err := initializers.DB.Model(&models.CasinoVendor{}).Where("vendor = ?", vendor.Vendor).
+		Count(&existing).Error; err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+	if existing > 0 {
+		format_errors.ConflictError(c, errors.New("casino vendor already exists"))
+		return
+	}
+
+	if err := initializers.DB.Create(&vendor).Error; err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Casino vendor created",
+		"data":    vendor,
+	})
+}
+
+// AdminUpdateCasinoVendor replaces the controls of a vendor
+func AdminUpdateCasinoVendor(c *gin.Context) {
+	var vendor models.CasinoVendor
+	if err := initializers.DB.First(&vendor, c.Param("id")).Error; err != nil {
+		format_errors.NotFound(c, err)
+		return
+	}
+	if !bindCasinoVendor(c, &vendor) {
+		return
+	}
+
+	if err := initializers.DB.Save(&vendor).Error; err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Casino vendor updated",
+		"data":    vendor,
+	})
+}
+
+// AdminDeleteCasinoVendor removes the controls of a vendor, opening it to everyone
+func AdminDeleteCasinoVendor(c *gin.Context) {
+	var vendor models.CasinoVendor
+	if err := initializers.DB.First(&vendor, c.Param("id")).Error; err != nil {
+		format_errors.NotFound(c, err)
+		return
+	}
+
+	// Unscoped so that the vendor can be created again
+	if err := initializers.DB.Unscoped().Delete(&vendor).Error; err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Casino vendor deleted",
+	})
+}
@@ -1,12 +1,15 @@
 package controllers
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"github.com/hotbrainy/go-betting/backend/db/initializers"
 	format_errors "github.com/hotbrainy/go-betting/backend/internal/format-errors"
 	"github.com/hotbrainy/go-betting/backend/internal/models"
+	"github.com/hotbrainy/go-betting/backend/internal/services"
+	"gorm.io/gorm/clause"
 )
 
 // CommentOnPost comments on a post
@@ -34,9 +37,9 @@ func ConvertPoint(c *gin.Context) {
 		}
 	}()
 
-	// Fetch and validate the transaction record exists
+	// Fetch and validate the transaction record exists, locked so it is approved once
 	var transaction models.Transaction
-	if err := tx.Where("id = ?", userInput.Id).First(&transaction).Error; err != nil {
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", userInput.Id).First(&transaction).Error; err != nil {
 		tx.Rollback()
 		format_errors.NotFound(c, err)
 		return
@@ -158,45 +161,22 @@ func ConvertRolling(c *gin.Context) {
 		return
 	}
 
-	// Fetch and validate the user profile exists
-	var profile models.Profile
-	if err := tx.Where("user_id = ?", userInput.UserId).First(&profile).Error; err != nil {
-		tx.Rollback()
-		format_errors.NotFound(c, err)
-		return
-	}
-
-	// Validate user has sufficient points for conversion
-	if float64(profile.Roll) < userInput.Amount {
+	if transaction.UserID != userInput.UserId {
 		tx.Rollback()
 		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Insufficient rolling for conversion",
+			"error": "Transaction does not belong to this user",
 		})
 		return
 	}
 
-	balanceBefore := profile.Balance
-
-	newRoll := float64(profile.Roll) - userInput.Amount
-	newBalance := profile.Balance + userInput.Amount
-
-	if err := tx.Model(&profile).Updates(models.Profile{
-		Roll:    newRoll,
-		Balance: newBalance,
-	}).Error; err != nil {
-		tx.Rollback()
-		format_errors.InternalServerError(c, err)
-		return
-	}
-
-	// Update transaction status from 'pending' to 'A' and add balance tracking
-	if err := tx.Model(&transaction).Updates(models.Transaction{
-		Status:        "A",
-		BalanceBefore: balanceBefore,
-		BalanceAfter:  newBalance,
-		Explation:     "Rolling conversion to balance",
-	}).Error; err != nil {
+	if err := services.CompleteRollingConversion(tx, &transaction, userInput.Amount); err != nil {
 		tx.Rollback()
+		if errors.Is(err, services.ErrRollingInsufficient) || errors.Is(err, services.ErrRollingNotPending) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
 		format_errors.InternalServerError(c, err)
 		return
 	}
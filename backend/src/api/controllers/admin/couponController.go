@@ -0,0 +1,410 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/hotbrainy/go-betting/backend/db/initializers"
+	format_errors "github.com/hotbrainy/go-betting/backend/internal/format-errors"
+	"github.com/hotbrainy/go-betting/backend/internal/helpers"
+	"github.com/hotbrainy/go-betting/backend/internal/models"
+	"github.com/hotbrainy/go-betting/backend/internal/services"
+	"github.com/hotbrainy/go-betting/backend/internal/validations"
+	"gorm.io/gorm"
+)
+
+type couponCampaignInput struct {
+	Name         string     `json:"name" binding:"required,min=2,max=100"`
+	Description  string     `json:"description"`
+	Type         string     `json:"type" binding:"required,oneof=cash point deposit_match"`
+	Amount       float64    `json:"amount" binding:"required,gt=0"`
+	MaxAmount    float64    `json:"maxAmount" binding:"min=0"`
+	Code         string     `json:"code" binding:"max=50"`
+	MinLevel     int        `json:"minLevel" binding:"min=0,max=15"`
+	MaxLevel     int        `json:"maxLevel" binding:"min=0,max=15"`
+	PerUserLimit int        `json:"perUserLimit" binding:"min=0"`
+	TotalLimit   int        `json:"totalLimit" binding:"min=0"`
+	ExpiresAt    *time.Time `json:"expiresAt"`
+	IsActive     bool       `json:"isActive"`
+}
+
+func (in couponCampaignInput) apply(campaign *models.CouponCampaign) {
+	campaign.Name = in.Name
+	campaign.Description = in.Description
+	campaign.Type = in.Type
+	campaign.Amount = in.Amount
+	campaign.MaxAmount = in.MaxAmount
+	campaign.MinLevel = in.MinLevel
+	campaign.MaxLevel = in.MaxLevel
+	campaign.PerUserLimit = in.PerUserLimit
+	campaign.TotalLimit = in.TotalLimit
+	campaign.ExpiresAt = in.ExpiresAt
+	campaign.IsActive = in.IsActive
+
+	code := strings.TrimSpace(in.Code)
+	if code == "" {
+		campaign.Code = nil
+	} else {
+		campaign.Code = &code
+	}
+}
+
+func bindCouponCampaignInput(c *gin.Context) (*couponCampaignInput, bool) {
+	var userInput couponCampaignInput
+	if err := c.ShouldBindJSON(&userInput); err != nil {
+		if errs, ok := err.(validator.ValidationErrors); ok {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{
+				"validations": validations.FormatValidationErrors(errs),
+			})
+			return nil, false
+		}
+		format_errors.BadRequestError(c, err)
+		return nil, false
+	}
+
+	if userInput.MaxLevel > 0 && userInput.MinLevel > userInput.MaxLevel {
+		format_errors.BadRequestError(c, fmt.Errorf("minLevel must not be greater than maxLevel"))
+		return nil, false
+	}
+	if userInput.Type == "deposit_match" && userInput.Amount > 100 {
+		format_errors.BadRequestError(c, fmt.Errorf("deposit_match amount is a percent and must not exceed 100"))
+		return nil, false
+	}
+
+	return &userInput, true
+}
+
+// GetCouponCampaigns lists coupon campaigns
+func GetCouponCampaigns(c *gin.Context) {
+	query := initializers.DB.Model(&models.CouponCampaign{})
+
+	if isActive := c.Query("isActive"); isActive != "" {
+		if active, err := strconv.ParseBool(isActive); err == nil {
+			query = query.Where("is_active = ?", active)
+		}
+	}
+
+	var campaigns []models.CouponCampaign
+	if err := query.Order("created_at DESC").Find(&campaigns).Error; err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    campaigns,
+	})
+}
+
+// CreateCouponCampaign creates a coupon campaign
+func CreateCouponCampaign(c *gin.Context) {
+	userInput, ok := bindCouponCampaignInput(c)
+	if !ok {
+		return
+	}
+
+	var campaign models.CouponCampaign
+	userInput.apply(&campaign)
+
+	if campaign.Code != nil && validations.IsUniqueValue("coupon_campaigns", "code", *campaign.Code) {
+		format_errors.ConflictError(c, fmt.Errorf("The coupon code is already in use"))
+		return
+	}
+
+	if err := initializers.DB.Create(&campaign).Error; err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Coupon campaign created successfully",
+		"data":    campaign,
+	})
+}
+
+// UpdateCouponCampaign updates a coupon campaign
+func UpdateCouponCampaign(c *gin.Context) {
+	var campaign models.CouponCampaign
+	if err := initializers.DB.First(&campaign, c.Param("id")).Error; err != nil {
+		format_errors.NotFound(c, err)
+		return
+	}
+
+	userInput, ok := bindCouponCampaignInput(c)
+	if !ok {
+		return
+	}
+
+	userInput.apply(&campaign)
+
+	if campaign.Code != nil {
+		var conflict int64
+		initializers.DB.Model(&models.CouponCampaign{}).Where("code = ? AND id <> ?", *campaign.Code, campaign.ID).Count(&conflict)
+		if conflict > 0 {
+			format_errors.ConflictError(c, fmt.Errorf("The coupon code is already in use"))
+			return
+		}
+	}
+
+	if err := initializers.DB.Save(&campaign).Error; err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Coupon campaign updated successfully",
+		"data":    campaign,
+	})
+}
+
+// DeleteCouponCampaign soft deletes a campaign and revokes its unredeemed coupons
+func DeleteCouponCampaign(c *gin.Context) {
+	var campaign models.CouponCampaign
+	if err := initializers.DB.First(&campaign, c.Param("id")).Error; err != nil {
+		format_errors.NotFound(c, err)
+		return
+	}
+
+	tx := initializers.DB.Begin()
+
+	var coupons []models.UserCoupon
+	if err := tx.Where("campaign_id = ? AND status = ?", campaign.ID, "issued").Find(&coupons).Error; err != nil {
+		tx.Rollback()
+		format_errors.InternalServerError(c, err)
+		return
+	}
+	for i := range coupons {
+		if err := revokeUserCoupon(tx, &coupons[i]); err != nil {
+			tx.Rollback()
+			format_errors.InternalServerError(c, err)
+			return
+		}
+	}
+
+	if err := tx.Delete(&campaign).Error; err != nil {
+		tx.Rollback()
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Coupon campaign deleted successfully",
+		"revoked": len(coupons),
+	})
+}
+
+// IssueCouponCampaign issues coupons of a campaign to a list of users or to every user of a level
+func IssueCouponCampaign(c *gin.Context) {
+	var userInput struct {
+		UserIDs []uint `json:"userIds"`
+		Level   int    `json:"level" binding:"min=0,max=15"`
+	}
+
+	if err := c.ShouldBindJSON(&userInput); err != nil {
+		if errs, ok := err.(validator.ValidationErrors); ok {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{
+				"validations": validations.FormatValidationErrors(errs),
+			})
+			return
+		}
+		format_errors.BadRequestError(c, err)
+		return
+	}
+
+	if len(userInput.UserIDs) == 0 && userInput.Level == 0 {
+		format_errors.BadRequestError(c, fmt.Errorf("userIds or level is required"))
+		return
+	}
+
+	var campaign models.CouponCampaign
+	if err := initializers.DB.First(&campaign, c.Param("id")).Error; err != nil {
+		format_errors.NotFound(c, err)
+		return
+	}
+
+	var issuedBy *uint
+	if admin, err := helpers.GetGinAuthUser(c); err == nil {
+		issuedBy = &admin.ID
+	}
+
+	source := "admin"
+	userIDs := userInput.UserIDs
+	if len(userIDs) == 0 {
+		source = "level"
+		if err := initializers.DB.Model(&models.Profile{}).
+			Joins("JOIN users ON users.id = profiles.user_id AND users.deleted_at IS NULL").
+			Where("profiles.level = ? AND users.role = ?", userInput.Level, "U").
+			Pluck("profiles.user_id", &userIDs).Error; err != nil {
+			format_errors.InternalServerError(c, err)
+			return
+		}
+	}
+
+	// Each user is issued in its own transaction so one failure does not block the rest
+	issued := 0
+	failed := map[uint]string{}
+	for _, userID := range userIDs {
+		tx := initializers.DB.Begin()
+		if _, err := services.IssueCoupon(tx, &campaign, userID, source, issuedBy); err != nil {
+			tx.Rollback()
+			// Reload counters in case the failed issue bumped them in memory
+			initializers.DB.First(&campaign, campaign.ID)
+			failed[userID] = err.Error()
+			continue
+		}
+		if err := tx.Commit().Error; err != nil {
+			initializers.DB.First(&campaign, campaign.ID)
+			failed[userID] = err.Error()
+			continue
+		}
+		issued++
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": fmt.Sprintf("%d coupons issued", issued),
+		"issued":  issued,
+		"failed":  failed,
+	})
+}
+
+// GetUserCoupons lists issued coupons across users
+func GetUserCoupons(c *gin.Context) {
+	query := initializers.DB.Model(&models.UserCoupon{})
+
+	if campaignID := c.Query("campaignId"); campaignID != "" {
+		query = query.Where("campaign_id = ?", campaignID)
+	}
+	if userID := c.Query("userId"); userID != "" {
+		query = query.Where("user_id = ?", userID)
+	}
+	if status := c.Query("status"); status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if page < 1 {
+		page = 1
+	}
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("pageSize", "20"))
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	var coupons []models.UserCoupon
+	if err := query.
+		Preload("Campaign").
+		Preload("User", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id, userid, name")
+		}).
+		Order("created_at DESC").
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Find(&coupons).Error; err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":  true,
+		"data":     coupons,
+		"total":    total,
+		"page":     page,
+		"pageSize": pageSize,
+	})
+}
+
+// RevokeUserCoupon revokes a single unredeemed coupon
+func RevokeUserCoupon(c *gin.Context) {
+	var coupon models.UserCoupon
+	if err := initializers.DB.First(&coupon, c.Param("id")).Error; err != nil {
+		format_errors.NotFound(c, err)
+		return
+	}
+
+	if coupon.Status != "issued" {
+		format_errors.BadRequestError(c, fmt.Errorf("Only issued coupons can be revoked"))
+		return
+	}
+
+	tx := initializers.DB.Begin()
+	if err := revokeUserCoupon(tx, &coupon); err != nil {
+		tx.Rollback()
+		format_errors.InternalServerError(c, err)
+		return
+	}
+	if err := tx.Commit().Error; err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Coupon revoked successfully",
+		"data":    coupon,
+	})
+}
+
+// GetCouponRedemptions returns the redemption history
+func GetCouponRedemptions(c *gin.Context) {
+	query := initializers.DB.Model(&models.CouponRedemption{})
+
+	if campaignID := c.Query("campaignId"); campaignID != "" {
+		query = query.Where("campaign_id = ?", campaignID)
+	}
+	if userID := c.Query("userId"); userID != "" {
+		query = query.Where("user_id = ?", userID)
+	}
+	if dateFrom := c.Query("dateFrom"); dateFrom != "" {
+		query = query.Where("created_at >= ?", dateFrom)
+	}
+	if dateTo := c.Query("dateTo"); dateTo != "" {
+		query = query.Where("created_at <= ?", dateTo)
+	}
+
+	var redemptions []models.CouponRedemption
+	if err := query.Order("created_at DESC").Limit(500).Find(&redemptions).Error; err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    redemptions,
+	})
+}
+
+// revokeUserCoupon marks an issued coupon as revoked and decrements the user's coupon counter
+func revokeUserCoupon(tx *gorm.DB, coupon *models.UserCoupon) error {
+	if err := tx.Model(coupon).Update("status", "revoked").Error; err != nil {
+		return err
+	}
+
+	var profile models.Profile
+	if err := tx.Where("user_id = ?", coupon.UserID).First(&profile).Error; err != nil {
+		return err
+	}
+	if profile.Coupon > 0 {
+		return tx.Model(&profile).Update("coupon", profile.Coupon-1).Error
+	}
+	return nil
+}
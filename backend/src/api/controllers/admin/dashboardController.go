@@ -1,14 +1,12 @@
 package controllers
 
 import (
-	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/hotbrainy/go-betting/backend/db/initializers"
+	"github.com/hotbrainy/go-betting/backend/internal/casino"
 	format_errors "github.com/hotbrainy/go-betting/backend/internal/format-errors"
 	"github.com/hotbrainy/go-betting/backend/internal/models"
 	dashboardModels "github.com/hotbrainy/go-betting/backend/models"
@@ -442,79 +440,15 @@ func GetDashboard(c *gin.Context) {
 
 	// 4.1. HonorLink balance
 	var honorLinkBalance float64 = 0
-	const (
-		baseURL     = "https://api.honorlink.org/api"
-		bearerToken = "srDiqct6lH61a0zHNKPUu0IwE0mg7Ht38sALu3oWb5bf8e9d"
-	)
-
-	// Make request to HonorLink API with proper error handling
-	reqURL := fmt.Sprintf("%s/my-info", baseURL)
-
-	req, err := http.NewRequest("GET", reqURL, nil)
-	if err != nil {
-		fmt.Printf("Failed to create request: %v\n", err)
-		honorLinkBalance = 0
-	} else {
-		req.Header.Set("Authorization", "Bearer "+bearerToken)
-		req.Header.Set("Content-Type", "application/json")
-
-		// Create HTTP client with timeout
-		client := &http.Client{
-			Timeout: 10 * time.Second,
-		}
-
-		resp, err := client.Do(req)
-		if err != nil {
-			fmt.Printf("Failed to make request: %v\n", err)
-			honorLinkBalance = 0
-		} else {
-			defer resp.Body.Close()
-
-			body, err := io.ReadAll(resp.Body)
-			if err != nil {
-				fmt.Printf("Failed to read response: %v\n", err)
+	if provider, err := casino.Get("honorlink"); err == nil {
+		if honorLink, ok := provider.(*casino.HonorLink); ok {
+			if honorLinkBalance, err = honorLink.AgentBalance(c.Request.Context()); err != nil {
+				fmt.Printf("Failed to get HonorLink balance: %v\n", err)
 				honorLinkBalance = 0
-			} else if resp.StatusCode != 200 {
-				fmt.Printf("Failed to get agent balance, status: %d, response: %s\n", resp.StatusCode, string(body))
-				honorLinkBalance = 0
-			} else {
-				// Parse response to get balance
-				var responseData map[string]interface{}
-				if err := json.Unmarshal(body, &responseData); err != nil {
-					fmt.Printf("Failed to parse response: %v\n", err)
-					honorLinkBalance = 0
-				} else {
-					// Safely extract balance from response with type checking
-					if balanceValue, exists := responseData["balance"]; exists {
-						switch v := balanceValue.(type) {
-						case float64:
-							honorLinkBalance = v
-						case int:
-							honorLinkBalance = float64(v)
-						case int64:
-							honorLinkBalance = float64(v)
-						case string:
-							if parsed, err := fmt.Sscanf(v, "%f", &honorLinkBalance); err != nil || parsed != 1 {
-								fmt.Printf("Failed to parse balance string: %v\n", err)
-								honorLinkBalance = 0
-							}
-						default:
-							fmt.Printf("Unexpected balance type: %T, value: %v\n", balanceValue, balanceValue)
-							honorLinkBalance = 0
-						}
-						fmt.Printf("HonorLink balance: %f\n", honorLinkBalance)
-
-					} else {
-						fmt.Printf("Balance field not found in response\n")
-						honorLinkBalance = 0
-					}
-				}
 			}
 		}
 	}
 
-	fmt.Println("honorLinkBalance", honorLinkBalance)
-
 	// Update stats with additional fields
 	response.Stats.ConnectedUsers = connectedUsers
 	response.Stats.TodaysSubscribers = todaysSubscribers
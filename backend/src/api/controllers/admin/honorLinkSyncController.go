@@ -0,0 +1,85 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/hotbrainy/go-betting/backend/db/initializers"
+	format_errors "github.com/hotbrainy/go-betting/backend/internal/format-errors"
+	"github.com/hotbrainy/go-betting/backend/internal/helpers"
+	"github.com/hotbrainy/go-betting/backend/internal/models"
+	"github.com/hotbrainy/go-betting/backend/internal/services"
+	"github.com/hotbrainy/go-betting/backend/internal/validations"
+)
+
+// AdminGetHonorLinkSync returns the HonorLink sync checkpoint, its lag, the latest backfills and
+// the latest transactions set aside for naming no local user
+func AdminGetHonorLinkSync(c *gin.Context) {
+	var backfills []models.HonorLinkBackfill
+	if err := initializers.DB.Order("id DESC").Limit(20).Find(&backfills).Error; err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	var unmatched []models.HonorLinkUnmatchedTransaction
+	if err := initializers.DB.Order("id DESC").Limit(50).Find(&unmatched).Error; err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": gin.H{
+			"sync":      services.GetHonorLinkSyncHealth(initializers.DB),
+			"backfills": backfills,
+			"unmatched": unmatched,
+		},
+	})
+}
+
+// AdminCreateHonorLinkBackfill queues a re-sync of HonorLink transactions for a time range,
+// picked up by the sync on its next run
+func AdminCreateHonorLinkBackfill(c *gin.Context) {
+	var userInput struct {
+		Start time.Time `json:"start" binding:"required"`
+		End   time.Time `json:"end" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&userInput); err != nil {
+		if errs, ok := err.(validator.ValidationErrors); ok {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{
+				"validations": validations.FormatValidationErrors(errs),
+			})
+			return
+		}
+		format_errors.BadRequestError(c, err)
+		return
+	}
+
+	admin, err := helpers.GetGinAuthUser(c)
+	if err != nil {
+		format_errors.UnauthorizedError(c, err)
+		return
+	}
+
+	backfill, err := services.CreateHonorLinkBackfill(initializers.DB, userInput.Start, userInput.End, &admin.ID)
+	if err != nil {
+		if errors.Is(err, services.ErrHonorLinkBackfillRange) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Backfill queued",
+		"data":    backfill,
+	})
+}
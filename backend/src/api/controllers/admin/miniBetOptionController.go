@@ -9,6 +9,7 @@ import (
 	"github.com/hotbrainy/go-betting/backend/db/initializers"
 	format_errors "github.com/hotbrainy/go-betting/backend/internal/format-errors"
 	"github.com/hotbrainy/go-betting/backend/internal/models"
+	"github.com/hotbrainy/go-betting/backend/internal/services"
 	"github.com/hotbrainy/go-betting/backend/internal/validations"
 )
 
@@ -128,6 +129,11 @@ func AdminGetMiniBetOptions(c *gin.Context) {
 		return
 	}
 
+	if err := services.ApplyEffectiveMiniOdds(initializers.DB, miniBetOptions); err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
 		"data":    miniBetOptions,
@@ -147,6 +153,13 @@ func AdminGetMiniBetOption(c *gin.Context) {
 		return
 	}
 
+	odds, err := services.EffectiveMiniBetOdds(initializers.DB, &miniBetOption)
+	if err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+	miniBetOption.Odds = odds
+
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
 		"data":    miniBetOption,
@@ -213,7 +226,6 @@ func AdminUpdateMiniBetOption(c *gin.Context) {
 
 	// Update the mini bet option
 	miniBetOption.Name = userInput.Name
-	miniBetOption.Odds = userInput.Odds
 	miniBetOption.Type = userInput.Type
 	miniBetOption.Ball = userInput.Ball
 	miniBetOption.Text = userInput.Text
@@ -224,12 +236,23 @@ func AdminUpdateMiniBetOption(c *gin.Context) {
 	miniBetOption.Enabled = userInput.Enabled
 	miniBetOption.OrderNum = userInput.OrderNum
 
-	result = initializers.DB.Save(&miniBetOption)
-
-	if err := result.Error; err != nil {
+	// Odds change through a new odds version so that placed bets keep the version they used
+	tx := initializers.DB.Begin()
+	if err := tx.Save(&miniBetOption).Error; err != nil {
+		tx.Rollback()
+		format_errors.InternalServerError(c, err)
+		return
+	}
+	if err := versionMiniOddsNow(c, tx, miniBetOption.GameType, map[uint]string{miniBetOption.ID: userInput.Odds}); err != nil {
+		tx.Rollback()
+		respondMiniOddsError(c, err)
+		return
+	}
+	if err := tx.Commit().Error; err != nil {
 		format_errors.InternalServerError(c, err)
 		return
 	}
+	miniBetOption.Odds = userInput.Odds
 
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
@@ -316,21 +339,44 @@ func AdminBulkUpdateMiniBetOptions(c *gin.Context) {
 		return
 	}
 
-	// Update each option
+	// Update each option, collecting odds changes per game type
+	tx := initializers.DB.Begin()
+	oddsChanges := map[string]map[uint]string{}
 	for _, option := range userInput.Options {
 		var miniBetOption models.MiniBetOption
-		result := initializers.DB.First(&miniBetOption, option.ID)
+		result := tx.First(&miniBetOption, option.ID)
 		if err := result.Error; err != nil {
 			continue // Skip if not found
 		}
 
 		miniBetOption.Enabled = option.Enabled
 		if option.Odds != "" {
-			miniBetOption.Odds = option.Odds
+			if oddsChanges[miniBetOption.GameType] == nil {
+				oddsChanges[miniBetOption.GameType] = map[uint]string{}
+			}
+			oddsChanges[miniBetOption.GameType][miniBetOption.ID] = option.Odds
 		}
 		miniBetOption.OrderNum = option.OrderNum
 
-		initializers.DB.Save(&miniBetOption)
+		if err := tx.Save(&miniBetOption).Error; err != nil {
+			tx.Rollback()
+			format_errors.InternalServerError(c, err)
+			return
+		}
+	}
+
+	// One odds version per game type
+	for gameType, odds := range oddsChanges {
+		if err := versionMiniOddsNow(c, tx, gameType, odds); err != nil {
+			tx.Rollback()
+			respondMiniOddsError(c, err)
+			return
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		format_errors.InternalServerError(c, err)
+		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
@@ -460,32 +506,7 @@ func AdminInitializeDefaultMiniBetOptions(c *gin.Context) {
 		return
 	}
 
-	// Create default powerball options
-	powerballOptions := []models.MiniBetOption{
-		{Name: "Powerball Odd", Odds: "1.95", Type: "single", Ball: stringPtr("blue"), Text: stringPtr("Odd"), GameType: userInput.GameType, Category: "powerball", Level: userInput.Level, Enabled: true, OrderNum: 1},
-		{Name: "Powerball Even", Odds: "1.95", Type: "single", Ball: stringPtr("red"), Text: stringPtr("Even"), GameType: userInput.GameType, Category: "powerball", Level: userInput.Level, Enabled: true, OrderNum: 2},
-		{Name: "Powerball Under", Odds: "1.95", Type: "single", Ball: stringPtr("blue"), Text: stringPtr("Under"), GameType: userInput.GameType, Category: "powerball", Level: userInput.Level, Enabled: true, OrderNum: 3},
-		{Name: "Powerball Over", Odds: "1.95", Type: "single", Ball: stringPtr("red"), Text: stringPtr("Over"), GameType: userInput.GameType, Category: "powerball", Level: userInput.Level, Enabled: true, OrderNum: 4},
-		{Name: "PaOdd-PaUnder", Odds: "4.1", Type: "combination", Balls: []models.BallOption{{Color: "blue", Text: "Odd"}, {Color: "blue", Text: "Under"}}, GameType: userInput.GameType, Category: "powerball", Level: userInput.Level, Enabled: true, OrderNum: 5},
-		{Name: "PaOdd-PaOver", Odds: "3.1", Type: "combination", Balls: []models.BallOption{{Color: "blue", Text: "Odd"}, {Color: "red", Text: "Over"}}, GameType: userInput.GameType, Category: "powerball", Level: userInput.Level, Enabled: true, OrderNum: 6},
-		{Name: "PaEven-PaUnder", Odds: "3.1", Type: "combination", Balls: []models.BallOption{{Color: "red", Text: "Even"}, {Color: "blue", Text: "Under"}}, GameType: userInput.GameType, Category: "powerball", Level: userInput.Level, Enabled: true, OrderNum: 7},
-		{Name: "PaEven-PaOver", Odds: "4.1", Type: "combination", Balls: []models.BallOption{{Color: "red", Text: "Even"}, {Color: "red", Text: "Over"}}, GameType: userInput.GameType, Category: "powerball", Level: userInput.Level, Enabled: true, OrderNum: 8},
-	}
-
-	// Create default normalball options
-	normalballOptions := []models.MiniBetOption{
-		{Name: "Normalball Odd", Odds: "1.95", Type: "single", Ball: stringPtr("blue"), Text: stringPtr("Odd"), GameType: userInput.GameType, Category: "normalball", Level: userInput.Level, Enabled: true, OrderNum: 9},
-		{Name: "Normalball Even", Odds: "1.95", Type: "single", Ball: stringPtr("red"), Text: stringPtr("Even"), GameType: userInput.GameType, Category: "normalball", Level: userInput.Level, Enabled: true, OrderNum: 10},
-		{Name: "Normalball Under", Odds: "1.95", Type: "single", Ball: stringPtr("blue"), Text: stringPtr("Under"), GameType: userInput.GameType, Category: "normalball", Level: userInput.Level, Enabled: true, OrderNum: 11},
-		{Name: "Normalball Over", Odds: "1.95", Type: "single", Ball: stringPtr("red"), Text: stringPtr("Over"), GameType: userInput.GameType, Category: "normalball", Level: userInput.Level, Enabled: true, OrderNum: 12},
-		{Name: "N-NUnder", Odds: "4.1", Type: "combination", Balls: []models.BallOption{{Color: "blue", Text: "Odd"}, {Color: "blue", Text: "Under"}}, GameType: userInput.GameType, Category: "normalball", Level: userInput.Level, Enabled: true, OrderNum: 13},
-		{Name: "N-NOver", Odds: "3.1", Type: "combination", Balls: []models.BallOption{{Color: "blue", Text: "Odd"}, {Color: "red", Text: "Over"}}, GameType: userInput.GameType, Category: "normalball", Level: userInput.Level, Enabled: true, OrderNum: 14},
-		{Name: "NOdd-NUnder", Odds: "3.1", Type: "combination", Balls: []models.BallOption{{Color: "red", Text: "Even"}, {Color: "blue", Text: "Under"}}, GameType: userInput.GameType, Category: "normalball", Level: userInput.Level, Enabled: true, OrderNum: 15},
-		{Name: "NEven-NOver", Odds: "4.1", Type: "combination", Balls: []models.BallOption{{Color: "red", Text: "Even"}, {Color: "red", Text: "Over"}}, GameType: userInput.GameType, Category: "normalball", Level: userInput.Level, Enabled: true, OrderNum: 16},
-	}
-
-	// Combine all options
-	allOptions := append(powerballOptions, normalballOptions...)
+	allOptions := models.DefaultMiniBetOptions(userInput.GameType, userInput.Level)
 
 	// Create all options
 	result := initializers.DB.Create(&allOptions)
@@ -501,8 +522,3 @@ func AdminInitializeDefaultMiniBetOptions(c *gin.Context) {
 		"message": "Default mini bet options created successfully",
 	})
 }
-
-// Helper function to create string pointer
-func stringPtr(s string) *string {
-	return &s
-}
@@ -0,0 +1,236 @@
+package controllers
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/hotbrainy/go-betting/backend/db/initializers"
+	format_errors "github.com/hotbrainy/go-betting/backend/internal/format-errors"
+	"github.com/hotbrainy/go-betting/backend/internal/helpers"
+	"github.com/hotbrainy/go-betting/backend/internal/minigame"
+	"github.com/hotbrainy/go-betting/backend/internal/models"
+	"github.com/hotbrainy/go-betting/backend/internal/services"
+	"github.com/hotbrainy/go-betting/backend/internal/validations"
+)
+
+// AdminGetMiniExposureLimits lists the exposure caps of every game type
+func AdminGetMiniExposureLimits(c *gin.Context) {
+	var limits []models.MiniGameExposureLimit
+	if err := initializers.DB.Order("game_type ASC").Find(&limits).Error; err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    limits,
+	})
+}
+
+// AdminUpdateMiniExposureLimit creates or updates the exposure caps of a game type
+func AdminUpdateMiniExposureLimit(c *gin.Context) {
+	var userInput struct {
+		GameType       string  `json:"gameType" binding:"required"`
+		MaxPickPayout  float64 `json:"maxPickPayout" binding:"min=0"`
+		MaxRoundPayout float64 `json:"maxRoundPayout" binding:"min=0"`
+		Action         string  `json:"action" binding:"required,oneof=reject suspend"`
+	}
+
+	if err := c.ShouldBindJSON(&userInput); err != nil {
+		if errs, ok := err.(validator.ValidationErrors); ok {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{
+				"validations": validations.FormatValidationErrors(errs),
+			})
+			return
+		}
+		format_errors.BadRequestError(c, err)
+		return
+	}
+
+	if _, ok := minigame.Schedules[userInput.GameType]; !ok {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid game type",
+		})
+		return
+	}
+
+	var limit models.MiniGameExposureLimit
+	result := initializers.DB.Where("game_type = ?", userInput.GameType).First(&limit)
+
+	limit.GameType = userInput.GameType
+	limit.MaxPickPayout = userInput.MaxPickPayout
+	limit.MaxRoundPayout = userInput.MaxRoundPayout
+	limit.Action = userInput.Action
+
+	if result.Error != nil {
+		result = initializers.DB.Create(&limit)
+	} else {
+		// Save so that zero caps are written as well
+		result = initializers.DB.Save(&limit)
+	}
+
+	if err := result.Error; err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Exposure limit updated successfully",
+		"data":    limit,
+	})
+}
+
+type miniExposurePick struct {
+	models.MiniGameExposure
+	Net       float64 `json:"net"`       // House result if this pick wins: round stake minus its payout
+	Usage     float64 `json:"usage"`     // Share of the pick cap used, in percent
+	Suspended bool    `json:"suspended"` // Closed for the rest of the round
+}
+
+// AdminGetMiniExposureBook returns the book of a round: stake and potential payout per pick
+// against the caps (?gameType=eos1min, current round unless &date= and &round= are given)
+func AdminGetMiniExposureBook(c *gin.Context) {
+	gameType := c.Query("gameType")
+
+	var round minigame.Round
+	var err error
+	if number, parseErr := strconv.ParseUint(c.Query("round"), 10, 32); parseErr == nil {
+		round, err = minigame.RoundByNumber(gameType, c.Query("date"), uint(number))
+	} else {
+		round, err = minigame.CurrentRound(c.Request.Context(), gameType)
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	var rows []models.MiniGameExposure
+	if err := initializers.DB.
+		Where("game_type = ? AND draw_date = ? AND round = ?", round.GameType, round.Date, round.Round).
+		Find(&rows).Error; err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	var suspensions []models.MiniGameSuspension
+	if err := initializers.DB.
+		Where("game_type = ? AND draw_date = ? AND round = ?", round.GameType, round.Date, round.Round).
+		Find(&suspensions).Error; err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+	suspended := map[string]bool{}
+	for _, suspension := range suspensions {
+		suspended[suspension.PickKey] = true
+	}
+
+	limit := services.GetMiniGameExposureLimit(initializers.DB, round.GameType)
+
+	var total models.MiniGameExposure
+	picks := []miniExposurePick{}
+	for _, row := range rows {
+		if row.PickKey == "" {
+			total = row
+			continue
+		}
+		picks = append(picks, miniExposurePick{MiniGameExposure: row, Suspended: suspended[row.PickKey]})
+	}
+	for i := range picks {
+		picks[i].Net = total.Stake - picks[i].Payout
+		if limit.MaxPickPayout > 0 {
+			picks[i].Usage = picks[i].Payout / limit.MaxPickPayout * 100
+		}
+	}
+	sort.Slice(picks, func(i, j int) bool {
+		return picks[i].Payout > picks[j].Payout
+	})
+
+	roundUsage := 0.0
+	if limit.MaxRoundPayout > 0 {
+		roundUsage = total.Payout / limit.MaxRoundPayout * 100
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": gin.H{
+			"round":       round,
+			"limit":       limit,
+			"bets":        total.Bets,
+			"stake":       total.Stake,
+			"payout":      total.Payout,
+			"roundUsage":  roundUsage,
+			"picks":       picks,
+			"suspensions": suspensions,
+		},
+	})
+}
+
+// AdminSuspendMiniPick closes a pick for the rest of the current round
+func AdminSuspendMiniPick(c *gin.Context) {
+	var userInput struct {
+		GameType string `json:"gameType" binding:"required"`
+		PickKey  string `json:"pickKey" binding:"required,max=100"`
+	}
+
+	if err := c.ShouldBindJSON(&userInput); err != nil {
+		if errs, ok := err.(validator.ValidationErrors); ok {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{
+				"validations": validations.FormatValidationErrors(errs),
+			})
+			return
+		}
+		format_errors.BadRequestError(c, err)
+		return
+	}
+
+	admin, err := helpers.GetGinAuthUser(c)
+	if err != nil {
+		format_errors.UnauthorizedError(c, err)
+		return
+	}
+
+	round, err := minigame.CurrentRound(c.Request.Context(), userInput.GameType)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid game type",
+		})
+		return
+	}
+
+	suspension, err := services.SuspendMiniPick(initializers.DB, round, userInput.PickKey, "admin", &admin.ID)
+	if err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Pick suspended for the current round",
+		"data":    suspension,
+	})
+}
+
+// AdminLiftMiniPickSuspension reopens a suspended pick
+func AdminLiftMiniPickSuspension(c *gin.Context) {
+	var suspension models.MiniGameSuspension
+	if err := initializers.DB.First(&suspension, c.Param("id")).Error; err != nil {
+		format_errors.NotFound(c, err)
+		return
+	}
+
+	if err := initializers.DB.Delete(&suspension).Error; err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Suspension lifted successfully",
+	})
+}
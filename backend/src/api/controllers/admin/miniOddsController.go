@@ -0,0 +1,227 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/hotbrainy/go-betting/backend/db/initializers"
+	format_errors "github.com/hotbrainy/go-betting/backend/internal/format-errors"
+	"github.com/hotbrainy/go-betting/backend/internal/helpers"
+	"github.com/hotbrainy/go-betting/backend/internal/minigame"
+	"github.com/hotbrainy/go-betting/backend/internal/models"
+	"github.com/hotbrainy/go-betting/backend/internal/services"
+	"github.com/hotbrainy/go-betting/backend/internal/validations"
+	"gorm.io/gorm"
+)
+
+// versionMiniOddsNow records odds edited in place as a version effective immediately,
+// attributed to the signed in admin. Unchanged odds are not an error.
+func versionMiniOddsNow(c *gin.Context, tx *gorm.DB, gameType string, odds map[uint]string) error {
+	admin, err := helpers.GetGinAuthUser(c)
+	if err != nil {
+		return err
+	}
+
+	_, err = services.CreateMiniOddsVersion(tx, gameType, time.Now(), "", &admin.ID, odds)
+	if errors.Is(err, services.ErrMiniOddsNoChanges) {
+		return nil
+	}
+	return err
+}
+
+func respondMiniOddsError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrMiniBetOptionOdds),
+		errors.Is(err, services.ErrMiniBetOptionNotFound),
+		errors.Is(err, services.ErrMiniOddsNoChanges):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+	case errors.Is(err, services.ErrMiniOddsVersionEffective):
+		c.JSON(http.StatusConflict, gin.H{
+			"error": err.Error(),
+		})
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		format_errors.NotFound(c, err)
+	default:
+		format_errors.InternalServerError(c, err)
+	}
+}
+
+func preloadMiniOddsCreator(db *gorm.DB) *gorm.DB {
+	return db.Select("ID, Name, Userid")
+}
+
+type miniOddsVersionView struct {
+	models.MiniOddsVersion
+	Scheduled bool `json:"scheduled"` // Not in effect yet
+}
+
+// AdminGetMiniOddsVersions lists the odds versions of a game type with their changes, newest
+// first (?gameType=eos1min&limit=50)
+func AdminGetMiniOddsVersions(c *gin.Context) {
+	gameType := c.Query("gameType")
+	if _, ok := minigame.Schedules[gameType]; !ok {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid game type",
+		})
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if err != nil || limit < 1 || limit > 500 {
+		limit = 50
+	}
+
+	var versions []models.MiniOddsVersion
+	if err := initializers.DB.
+		Preload("Creator", preloadMiniOddsCreator).
+		Preload("Changes.Option").
+		Where("game_type = ?", gameType).
+		Order("effective_from DESC, id DESC").
+		Limit(limit).
+		Find(&versions).Error; err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	now := time.Now()
+	data := make([]miniOddsVersionView, 0, len(versions))
+	for _, version := range versions {
+		data = append(data, miniOddsVersionView{
+			MiniOddsVersion: version,
+			Scheduled:       version.EffectiveFrom.After(now),
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    data,
+	})
+}
+
+// AdminCreateMiniOddsVersion schedules new odds for bet options of a game type. Without
+// effectiveFrom the odds take effect immediately.
+func AdminCreateMiniOddsVersion(c *gin.Context) {
+	var userInput struct {
+		GameType      string     `json:"gameType" binding:"required"`
+		EffectiveFrom *time.Time `json:"effectiveFrom"`
+		Note          string     `json:"note" binding:"max=500"`
+		Changes       []struct {
+			OptionID uint   `json:"optionId" binding:"required"`
+			Odds     string `json:"odds" binding:"required"`
+		} `json:"changes" binding:"required,min=1,dive"`
+	}
+
+	if err := c.ShouldBindJSON(&userInput); err != nil {
+		if errs, ok := err.(validator.ValidationErrors); ok {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{
+				"validations": validations.FormatValidationErrors(errs),
+			})
+			return
+		}
+		format_errors.BadRequestError(c, err)
+		return
+	}
+
+	admin, err := helpers.GetGinAuthUser(c)
+	if err != nil {
+		format_errors.UnauthorizedError(c, err)
+		return
+	}
+
+	effectiveFrom := time.Now()
+	if userInput.EffectiveFrom != nil && userInput.EffectiveFrom.After(effectiveFrom) {
+		effectiveFrom = *userInput.EffectiveFrom
+	}
+
+	odds := map[uint]string{}
+	for _, change := range userInput.Changes {
+		odds[change.OptionID] = change.Odds
+	}
+
+	tx := initializers.DB.Begin()
+	version, err := services.CreateMiniOddsVersion(tx, userInput.GameType, effectiveFrom, userInput.Note, &admin.ID, odds)
+	if err != nil {
+		tx.Rollback()
+		respondMiniOddsError(c, err)
+		return
+	}
+	if err := tx.Commit().Error; err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Odds version created successfully",
+		"data":    version,
+	})
+}
+
+// AdminCancelMiniOddsVersion cancels an odds version that has not taken effect yet
+func AdminCancelMiniOddsVersion(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		format_errors.BadRequestError(c, err)
+		return
+	}
+
+	if err := services.CancelMiniOddsVersion(initializers.DB, uint(id)); err != nil {
+		respondMiniOddsError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Odds version cancelled successfully",
+	})
+}
+
+// AdminGetMiniBetOptionOddsHistory lists every odds change of a bet option, newest first
+func AdminGetMiniBetOptionOddsHistory(c *gin.Context) {
+	var option models.MiniBetOption
+	if err := initializers.DB.First(&option, c.Param("id")).Error; err != nil {
+		format_errors.NotFound(c, err)
+		return
+	}
+
+	var versions []models.MiniOddsVersion
+	if err := initializers.DB.
+		Preload("Creator", preloadMiniOddsCreator).
+		Preload("Changes", "option_id = ?", option.ID).
+		Where("id IN (?)", initializers.DB.Model(&models.MiniOddsChange{}).Select("version_id").Where("option_id = ?", option.ID)).
+		Order("effective_from DESC, id DESC").
+		Find(&versions).Error; err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	current, err := services.EffectiveMiniBetOdds(initializers.DB, &option)
+	if err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	now := time.Now()
+	history := make([]miniOddsVersionView, 0, len(versions))
+	for _, version := range versions {
+		history = append(history, miniOddsVersionView{
+			MiniOddsVersion: version,
+			Scheduled:       version.EffectiveFrom.After(now),
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": gin.H{
+			"option":      option,
+			"currentOdds": current,
+			"history":     history,
+		},
+	})
+}
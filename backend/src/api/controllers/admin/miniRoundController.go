@@ -0,0 +1,278 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/hotbrainy/go-betting/backend/db/initializers"
+	format_errors "github.com/hotbrainy/go-betting/backend/internal/format-errors"
+	"github.com/hotbrainy/go-betting/backend/internal/helpers"
+	"github.com/hotbrainy/go-betting/backend/internal/minigame"
+	"github.com/hotbrainy/go-betting/backend/internal/models"
+	"github.com/hotbrainy/go-betting/backend/internal/services"
+	"github.com/hotbrainy/go-betting/backend/internal/validations"
+)
+
+// AdminGetMiniSettlementConfig returns the mini-game settlement config
+func AdminGetMiniSettlementConfig(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    services.GetMiniGameSettlementConfig(initializers.DB),
+	})
+}
+
+// AdminUpdateMiniSettlementConfig updates the mini-game settlement config
+func AdminUpdateMiniSettlementConfig(c *gin.Context) {
+	var userInput struct {
+		CancelAfterMinutes int `json:"cancelAfterMinutes" binding:"min=0,max=1440"`
+	}
+
+	if err := c.ShouldBindJSON(&userInput); err != nil {
+		if errs, ok := err.(validator.ValidationErrors); ok {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{
+				"validations": validations.FormatValidationErrors(errs),
+			})
+			return
+		}
+		format_errors.BadRequestError(c, err)
+		return
+	}
+
+	var config models.MiniGameSettlementConfig
+	result := initializers.DB.First(&config)
+
+	config.CancelAfterMinutes = userInput.CancelAfterMinutes
+
+	if result.Error != nil {
+		result = initializers.DB.Create(&config)
+	} else {
+		// Save so that 0 (never cancel) is written as well
+		result = initializers.DB.Save(&config)
+	}
+
+	if err := result.Error; err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Settlement config updated successfully",
+		"data":    config,
+	})
+}
+
+type pendingMiniRound struct {
+	minigame.Round
+	Bets   int     `json:"bets"`
+	Amount float64 `json:"amount"`
+}
+
+// AdminGetPendingMiniRounds lists drawn rounds that still have unsettled bets, oldest first
+func AdminGetPendingMiniRounds(c *gin.Context) {
+	now := time.Now()
+
+	var bets []models.PowerballHistory
+	query := initializers.DB.Select("game_type", "amount", "created_at").Where("status = ?", "pending")
+	if gameType := c.Query("gameType"); gameType != "" {
+		query = query.Where("game_type = ?", gameType)
+	}
+	if err := query.Find(&bets).Error; err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	rounds := map[string]*pendingMiniRound{}
+	for _, bet := range bets {
+		round, err := minigame.RoundAt(bet.GameType, bet.CreatedAt)
+		if err != nil || now.Before(round.DrawAt) {
+			continue
+		}
+		key := round.GameType + ":" + round.Date + ":" + strconv.FormatUint(uint64(round.Round), 10)
+		if rounds[key] == nil {
+			rounds[key] = &pendingMiniRound{Round: round}
+		}
+		rounds[key].Bets++
+		rounds[key].Amount += bet.Amount
+	}
+
+	data := make([]pendingMiniRound, 0, len(rounds))
+	for _, round := range rounds {
+		data = append(data, *round)
+	}
+	sort.Slice(data, func(i, j int) bool {
+		return data[i].DrawAt.Before(data[j].DrawAt)
+	})
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    data,
+	})
+}
+
+func respondMiniRoundError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrMiniRoundOpen):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+	default:
+		format_errors.InternalServerError(c, err)
+	}
+}
+
+// AdminCancelMiniRound cancels a round and refunds its pending bets
+func AdminCancelMiniRound(c *gin.Context) {
+	var userInput struct {
+		GameType string `json:"gameType" binding:"required"`
+		Date     string `json:"date" binding:"required"`
+		Round    uint   `json:"round" binding:"required,min=1"`
+		Reason   string `json:"reason" binding:"required,max=500"`
+	}
+
+	if err := c.ShouldBindJSON(&userInput); err != nil {
+		if errs, ok := err.(validator.ValidationErrors); ok {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{
+				"validations": validations.FormatValidationErrors(errs),
+			})
+			return
+		}
+		format_errors.BadRequestError(c, err)
+		return
+	}
+
+	admin, err := helpers.GetGinAuthUser(c)
+	if err != nil {
+		format_errors.UnauthorizedError(c, err)
+		return
+	}
+
+	round, err := minigame.RoundByNumber(userInput.GameType, userInput.Date, userInput.Round)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	tx := initializers.DB.Begin()
+	action, err := services.CancelMiniRound(tx, round, "cancel", userInput.Reason, &admin.ID)
+	if err != nil {
+		tx.Rollback()
+		respondMiniRoundError(c, err)
+		return
+	}
+	if err := tx.Commit().Error; err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Round cancelled and bets refunded",
+		"data":    action,
+	})
+}
+
+// AdminSettleMiniRound settles a round with a result entered by hand
+func AdminSettleMiniRound(c *gin.Context) {
+	var userInput struct {
+		GameType  string `json:"gameType" binding:"required"`
+		Date      string `json:"date" binding:"required"`
+		Round     uint   `json:"round" binding:"required,min=1"`
+		Balls     []int  `json:"balls" binding:"required,len=5,dive,min=1,max=28"`
+		PowerBall *int   `json:"powerBall" binding:"required,min=0,max=9"`
+		Reason    string `json:"reason" binding:"required,max=500"`
+	}
+
+	if err := c.ShouldBindJSON(&userInput); err != nil {
+		if errs, ok := err.(validator.ValidationErrors); ok {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{
+				"validations": validations.FormatValidationErrors(errs),
+			})
+			return
+		}
+		format_errors.BadRequestError(c, err)
+		return
+	}
+
+	draw := minigame.HouseDraw{PowerBall: *userInput.PowerBall}
+	seen := map[int]bool{}
+	for i, ball := range userInput.Balls {
+		if seen[ball] {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Normal balls must be distinct",
+			})
+			return
+		}
+		seen[ball] = true
+		draw.Balls[i] = ball
+	}
+
+	admin, err := helpers.GetGinAuthUser(c)
+	if err != nil {
+		format_errors.UnauthorizedError(c, err)
+		return
+	}
+
+	round, err := minigame.RoundByNumber(userInput.GameType, userInput.Date, userInput.Round)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	if time.Now().Before(round.DrawAt) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Round has not been drawn yet",
+		})
+		return
+	}
+
+	action, err := services.SettleMiniRoundManually(initializers.DB, round, draw, userInput.Reason, admin.ID)
+	if err != nil {
+		respondMiniRoundError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Round settled with the entered result",
+		"data":    action,
+	})
+}
+
+// AdminGetMiniRoundActions lists the round audit trail, newest first (?gameType=&date=&round=&limit=50)
+func AdminGetMiniRoundActions(c *gin.Context) {
+	query := initializers.DB.Preload("Admin")
+	if gameType := c.Query("gameType"); gameType != "" {
+		query = query.Where("game_type = ?", gameType)
+	}
+	if date := c.Query("date"); date != "" {
+		query = query.Where("draw_date = ?", date)
+	}
+	if round, err := strconv.ParseUint(c.Query("round"), 10, 32); err == nil {
+		query = query.Where("round = ?", round)
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if err != nil || limit < 1 || limit > 500 {
+		limit = 50
+	}
+
+	var actions []models.MiniGameRoundAction
+	if err := query.Order("created_at DESC").Limit(limit).Find(&actions).Error; err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    actions,
+	})
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/hotbrainy/go-betting/backend/internal/helpers"
 	"github.com/hotbrainy/go-betting/backend/internal/models"
 	"github.com/hotbrainy/go-betting/backend/internal/pagination"
+	"github.com/hotbrainy/go-betting/backend/internal/services"
 	"github.com/hotbrainy/go-betting/backend/internal/validations"
 	"gorm.io/gorm"
 )
@@ -217,6 +218,7 @@ func UpdatePost(c *gin.Context) {
 	})
 }
 
+// DeletePost deletes a post; with ?spam=true the points paid for writing it are taken back
 func DeletePost(c *gin.Context) {
 	// Get the id from the url
 	id := c.Param("id")
@@ -228,17 +230,36 @@ func DeletePost(c *gin.Context) {
 		return
 	}
 
+	isSpam, _ := strconv.ParseBool(c.DefaultQuery("spam", "false"))
+
+	tx := initializers.DB.Begin()
+
 	// Delete the post
-	result = initializers.DB.Delete(&post)
+	if err := tx.Delete(&post).Error; err != nil {
+		tx.Rollback()
+		format_errors.InternalServerError(c, err)
+		return
+	}
 
-	if err := result.Error; err != nil {
+	recovered := 0.0
+	if isSpam {
+		var err error
+		if recovered, err = services.ClawbackPostPoints(tx, post.ID); err != nil {
+			tx.Rollback()
+			format_errors.InternalServerError(c, err)
+			return
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
 		format_errors.InternalServerError(c, err)
 		return
 	}
 
 	// Return response
 	c.JSON(http.StatusOK, gin.H{
-		"message": "The post has been deleted successfully",
+		"message":         "The post has been deleted successfully",
+		"pointsRecovered": recovered,
 	})
 }
 
@@ -0,0 +1,353 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/hotbrainy/go-betting/backend/db/initializers"
+	format_errors "github.com/hotbrainy/go-betting/backend/internal/format-errors"
+	"github.com/hotbrainy/go-betting/backend/internal/models"
+	"github.com/hotbrainy/go-betting/backend/internal/services"
+	"github.com/hotbrainy/go-betting/backend/internal/validations"
+	"gorm.io/gorm"
+)
+
+type rouletteSegmentInput struct {
+	Label            string  `json:"label" binding:"required,min=1,max=100"`
+	Color            string  `json:"color" binding:"max=20"`
+	SortOrder        int     `json:"sortOrder"`
+	Weight           int     `json:"weight" binding:"min=0"`
+	PrizeType        string  `json:"prizeType" binding:"required,oneof=point coupon cash none"`
+	Amount           float64 `json:"amount" binding:"min=0"`
+	CouponCampaignID *uint   `json:"couponCampaignId"`
+	IsActive         bool    `json:"isActive"`
+}
+
+func (in rouletteSegmentInput) apply(segment *models.RouletteSegment) {
+	segment.Label = in.Label
+	segment.Color = in.Color
+	segment.SortOrder = in.SortOrder
+	segment.Weight = in.Weight
+	segment.PrizeType = in.PrizeType
+	segment.Amount = in.Amount
+	segment.CouponCampaignID = in.CouponCampaignID
+	segment.IsActive = in.IsActive
+
+	if in.PrizeType != "coupon" {
+		segment.CouponCampaignID = nil
+	}
+}
+
+func bindRouletteSegmentInput(c *gin.Context) (*rouletteSegmentInput, bool) {
+	var userInput rouletteSegmentInput
+	if err := c.ShouldBindJSON(&userInput); err != nil {
+		if errs, ok := err.(validator.ValidationErrors); ok {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{
+				"validations": validations.FormatValidationErrors(errs),
+			})
+			return nil, false
+		}
+		format_errors.BadRequestError(c, err)
+		return nil, false
+	}
+
+	if userInput.PrizeType == "coupon" {
+		if userInput.CouponCampaignID == nil || !validations.IsExistValue("coupon_campaigns", "id", *userInput.CouponCampaignID) {
+			format_errors.BadRequestError(c, fmt.Errorf("A valid couponCampaignId is required for coupon prizes"))
+			return nil, false
+		}
+	}
+
+	return &userInput, true
+}
+
+// GetRouletteConfig returns the global roulette config
+func GetRouletteConfig(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    services.GetRouletteConfig(initializers.DB),
+	})
+}
+
+// UpdateRouletteConfig creates or updates the global roulette config
+func UpdateRouletteConfig(c *gin.Context) {
+	var userInput struct {
+		IsActive        bool    `json:"isActive"`
+		DailySpins      int     `json:"dailySpins" binding:"min=0"`
+		DepositSpinUnit float64 `json:"depositSpinUnit" binding:"min=0"`
+		MaxDepositSpins int     `json:"maxDepositSpins" binding:"min=0"`
+		AttendanceSpins int     `json:"attendanceSpins" binding:"min=0"`
+		SpinValidDays   int     `json:"spinValidDays" binding:"min=0"`
+	}
+
+	if err := c.ShouldBindJSON(&userInput); err != nil {
+		if errs, ok := err.(validator.ValidationErrors); ok {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{
+				"validations": validations.FormatValidationErrors(errs),
+			})
+			return
+		}
+		format_errors.BadRequestError(c, err)
+		return
+	}
+
+	var config models.RouletteConfig
+	result := initializers.DB.First(&config)
+
+	config.IsActive = userInput.IsActive
+	config.DailySpins = userInput.DailySpins
+	config.DepositSpinUnit = userInput.DepositSpinUnit
+	config.MaxDepositSpins = userInput.MaxDepositSpins
+	config.AttendanceSpins = userInput.AttendanceSpins
+	config.SpinValidDays = userInput.SpinValidDays
+
+	if result.Error != nil {
+		result = initializers.DB.Create(&config)
+	} else {
+		// Save so that false / zero values are written as well
+		result = initializers.DB.Save(&config)
+	}
+
+	if err := result.Error; err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Roulette config updated successfully",
+		"data":    config,
+	})
+}
+
+// GetRouletteSegments lists the wheel segments with each one's share of the total weight
+func GetRouletteSegments(c *gin.Context) {
+	var segments []models.RouletteSegment
+	if err := initializers.DB.Order("sort_order ASC, id ASC").Find(&segments).Error; err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	totalWeight := 0
+	for _, segment := range segments {
+		if segment.IsActive {
+			totalWeight += segment.Weight
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":     true,
+		"data":        segments,
+		"totalWeight": totalWeight,
+	})
+}
+
+// CreateRouletteSegment adds a segment to the wheel
+func CreateRouletteSegment(c *gin.Context) {
+	userInput, ok := bindRouletteSegmentInput(c)
+	if !ok {
+		return
+	}
+
+	var segment models.RouletteSegment
+	userInput.apply(&segment)
+
+	if err := initializers.DB.Create(&segment).Error; err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Roulette segment created successfully",
+		"data":    segment,
+	})
+}
+
+// UpdateRouletteSegment updates a wheel segment. Past spins keep the weights they were drawn with.
+func UpdateRouletteSegment(c *gin.Context) {
+	var segment models.RouletteSegment
+	if err := initializers.DB.First(&segment, c.Param("id")).Error; err != nil {
+		format_errors.NotFound(c, err)
+		return
+	}
+
+	userInput, ok := bindRouletteSegmentInput(c)
+	if !ok {
+		return
+	}
+	userInput.apply(&segment)
+
+	if err := initializers.DB.Save(&segment).Error; err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Roulette segment updated successfully",
+		"data":    segment,
+	})
+}
+
+// DeleteRouletteSegment removes a segment from the wheel
+func DeleteRouletteSegment(c *gin.Context) {
+	var segment models.RouletteSegment
+	if err := initializers.DB.First(&segment, c.Param("id")).Error; err != nil {
+		format_errors.NotFound(c, err)
+		return
+	}
+
+	if err := initializers.DB.Delete(&segment).Error; err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Roulette segment deleted successfully",
+	})
+}
+
+// GrantRouletteSpins grants spins to users manually
+func GrantRouletteSpins(c *gin.Context) {
+	var userInput struct {
+		UserIDs   []uint `json:"userIds" binding:"required,min=1"`
+		Spins     int    `json:"spins" binding:"required,min=1,max=100"`
+		ValidDays int    `json:"validDays" binding:"min=0"`
+	}
+
+	if err := c.ShouldBindJSON(&userInput); err != nil {
+		if errs, ok := err.(validator.ValidationErrors); ok {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{
+				"validations": validations.FormatValidationErrors(errs),
+			})
+			return
+		}
+		format_errors.BadRequestError(c, err)
+		return
+	}
+
+	var expiresAt *time.Time
+	if userInput.ValidDays > 0 {
+		t := time.Now().AddDate(0, 0, userInput.ValidDays)
+		expiresAt = &t
+	}
+
+	// Each manual grant is its own entitlement
+	sourceRef := strconv.FormatInt(time.Now().UnixNano(), 10)
+
+	tx := initializers.DB.Begin()
+	for _, userID := range userInput.UserIDs {
+		if err := services.GrantRouletteSpins(tx, userID, "admin", sourceRef, userInput.Spins, expiresAt); err != nil {
+			tx.Rollback()
+			format_errors.InternalServerError(c, err)
+			return
+		}
+	}
+	if err := tx.Commit().Error; err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": fmt.Sprintf("%d spins granted to %d users", userInput.Spins, len(userInput.UserIDs)),
+	})
+}
+
+// GetRouletteSpins returns the spin history across users
+func GetRouletteSpins(c *gin.Context) {
+	query := initializers.DB.Model(&models.RouletteSpin{})
+
+	if userID := c.Query("userId"); userID != "" {
+		query = query.Where("user_id = ?", userID)
+	}
+	if prizeType := c.Query("prizeType"); prizeType != "" {
+		query = query.Where("prize_type = ?", prizeType)
+	}
+	if status := c.Query("status"); status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if dateFrom := c.Query("dateFrom"); dateFrom != "" {
+		query = query.Where("created_at >= ?", dateFrom)
+	}
+	if dateTo := c.Query("dateTo"); dateTo != "" {
+		query = query.Where("created_at <= ?", dateTo)
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if page < 1 {
+		page = 1
+	}
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("pageSize", "20"))
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	var spins []models.RouletteSpin
+	if err := query.
+		Preload("User", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id, userid, name")
+		}).
+		Order("created_at DESC").
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Find(&spins).Error; err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":  true,
+		"data":     spins,
+		"total":    total,
+		"page":     page,
+		"pageSize": pageSize,
+	})
+}
+
+// VerifyRouletteSpin recomputes any spin from its seed, revealed or not
+func VerifyRouletteSpin(c *gin.Context) {
+	var spin models.RouletteSpin
+	if err := initializers.DB.First(&spin, c.Param("id")).Error; err != nil {
+		format_errors.NotFound(c, err)
+		return
+	}
+
+	var seed models.RouletteSeed
+	if err := initializers.DB.First(&seed, spin.SeedID).Error; err != nil {
+		format_errors.NotFound(c, err)
+		return
+	}
+
+	roll := services.RouletteRoll(seed.ServerSeed, seed.ClientSeed, spin.Nonce)
+	var segmentID uint
+	if index := services.PickRouletteSegment(spin.Segments, roll); index >= 0 {
+		segmentID = spin.Segments[index].ID
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": gin.H{
+			"spin":           spin,
+			"serverSeed":     seed.ServerSeed,
+			"serverSeedHash": seed.ServerSeedHash,
+			"clientSeed":     seed.ClientSeed,
+			"revealedAt":     seed.RevealedAt,
+			"roll":           roll,
+			"segmentId":      segmentID,
+			"verified":       roll == spin.Roll && segmentID == spin.SegmentID,
+		},
+	})
+}
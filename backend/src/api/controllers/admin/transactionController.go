@@ -7,6 +7,7 @@ import (
 	"github.com/hotbrainy/go-betting/backend/db/initializers"
 	format_errors "github.com/hotbrainy/go-betting/backend/internal/format-errors"
 	"github.com/hotbrainy/go-betting/backend/internal/models"
+	"github.com/hotbrainy/go-betting/backend/internal/services"
 )
 
 // Deposit the amount to balance then add the transaction table.
@@ -68,6 +69,12 @@ func Deposit(c *gin.Context) {
 		return
 	}
 
+	if err := services.GrantDepositRouletteSpins(tx, userInput.UserId, transaction.ID, userInput.Amount); err != nil {
+		tx.Rollback()
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
 	// Commit the transaction
 	if err := tx.Commit().Error; err != nil {
 		format_errors.InternalServerError(c, err)
@@ -10,6 +10,7 @@ import (
 	format_errors "github.com/hotbrainy/go-betting/backend/internal/format-errors"
 	"github.com/hotbrainy/go-betting/backend/internal/models"
 	"github.com/hotbrainy/go-betting/backend/internal/pagination"
+	"github.com/hotbrainy/go-betting/backend/internal/services"
 	"github.com/hotbrainy/go-betting/backend/internal/validations"
 	"gorm.io/plugin/dbresolver"
 )
@@ -193,12 +194,27 @@ func PermanentlyDeleteUser(c *gin.Context) {
 	})
 }
 
-// ResetAllCoupons function is used to reset all user coupons to 0
+// ResetAllCoupons function is used to revoke all unredeemed coupons and reset all user coupons to 0
 func ResetAllCoupons(c *gin.Context) {
+	tx := initializers.DB.Begin()
+
+	// Revoke every coupon that has not been redeemed yet
+	if err := tx.Model(&models.UserCoupon{}).Where("status = ?", "issued").Update("status", "revoked").Error; err != nil {
+		tx.Rollback()
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
 	// Update all profiles to set coupon to 0
-	result := initializers.DB.Model(&models.Profile{}).Update("coupon", 0)
+	result := tx.Model(&models.Profile{}).Where("coupon <> ?", 0).Update("coupon", 0)
 
 	if err := result.Error; err != nil {
+		tx.Rollback()
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	if err := tx.Commit().Error; err != nil {
 		format_errors.InternalServerError(c, err)
 		return
 	}
@@ -210,3 +226,17 @@ func ResetAllCoupons(c *gin.Context) {
 		"count":   result.RowsAffected,
 	})
 }
+
+// RebuildUserBettingStats recomputes every user's casino and mini game betting and winning
+// statistics from the recorded bets
+func RebuildUserBettingStats(c *gin.Context) {
+	if err := services.RebuildBettingStats(initializers.DB); err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Betting statistics rebuilt",
+	})
+}
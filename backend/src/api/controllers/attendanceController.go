@@ -0,0 +1,324 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hotbrainy/go-betting/backend/db/initializers"
+	format_errors "github.com/hotbrainy/go-betting/backend/internal/format-errors"
+	"github.com/hotbrainy/go-betting/backend/internal/helpers"
+	"github.com/hotbrainy/go-betting/backend/internal/models"
+	"github.com/hotbrainy/go-betting/backend/internal/services"
+	"gorm.io/gorm"
+)
+
+// getAttendanceConfig returns the stored attendance config or the defaults if none exists
+func getAttendanceConfig() models.AttendanceConfig {
+	var config models.AttendanceConfig
+	if err := initializers.DB.First(&config).Error; err != nil {
+		config = models.AttendanceConfig{
+			IsActive:     true,
+			DayStartHour: 0,
+			MaxPerDevice: 1,
+			MaxPerIP:     3,
+		}
+	}
+	return config
+}
+
+// CheckInAttendance records today's attendance for the authenticated user and pays streak rewards
+func CheckInAttendance(c *gin.Context) {
+	user, err := helpers.GetGinAuthUser(c)
+	if err != nil {
+		format_errors.UnauthorizedError(c, err)
+		return
+	}
+
+	var userInput struct {
+		DeviceID string `json:"deviceId"`
+	}
+	// Body is optional
+	_ = c.ShouldBindJSON(&userInput)
+
+	if user.UseAttendanceCheck == "2" {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": "Attendance check is not available for this account",
+		})
+		return
+	}
+
+	config := getAttendanceConfig()
+	if !config.IsActive {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": "Attendance check is currently disabled",
+		})
+		return
+	}
+
+	deviceID := userInput.DeviceID
+	if deviceID == "" {
+		deviceID = user.FingerPrint
+	}
+	clientIP := c.ClientIP()
+
+	now := time.Now()
+	today := config.BusinessDay(now)
+
+	// Only one check-in per business day; repeated attempts are counted
+	var existing models.Attendance
+	if err := initializers.DB.Where("user_id = ? AND attendance_date = ?", user.ID, today).First(&existing).Error; err == nil {
+		initializers.DB.Model(&existing).Update("request_count", existing.RequestCount+1)
+		c.JSON(http.StatusConflict, gin.H{
+			"error": "Already checked in today",
+			"data":  existing,
+		})
+		return
+	}
+
+	// Device and IP caps are per business day and count other users only
+	if config.MaxPerDevice > 0 && deviceID != "" {
+		var deviceCount int64
+		initializers.DB.Model(&models.Attendance{}).
+			Where("attendance_date = ? AND device_id = ? AND user_id <> ?", today, deviceID, user.ID).
+			Count(&deviceCount)
+		if deviceCount >= int64(config.MaxPerDevice) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Attendance limit reached for this device today",
+			})
+			return
+		}
+	}
+
+	if config.MaxPerIP > 0 {
+		var ipCount int64
+		initializers.DB.Model(&models.Attendance{}).
+			Where("attendance_date = ? AND ip = ? AND user_id <> ?", today, clientIP, user.ID).
+			Count(&ipCount)
+		if ipCount >= int64(config.MaxPerIP) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Attendance limit reached for this IP today",
+			})
+			return
+		}
+	}
+
+	// Load active rewards to find the milestone for this streak and the cycle length
+	var rewards []models.AttendanceReward
+	if err := initializers.DB.Where("is_active = ?", true).Order("streak_days ASC").Find(&rewards).Error; err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+	maxStreak := 0
+	if len(rewards) > 0 {
+		maxStreak = rewards[len(rewards)-1].StreakDays
+	}
+
+	// Continue the streak if the user checked in on the previous business day
+	streak := 1
+	todayDate, _ := time.Parse("2006-01-02", today)
+	yesterday := todayDate.AddDate(0, 0, -1).Format("2006-01-02")
+	var previous models.Attendance
+	if err := initializers.DB.Where("user_id = ? AND attendance_date = ?", user.ID, yesterday).First(&previous).Error; err == nil {
+		streak = previous.Streak + 1
+		// Start a new cycle once the longest configured streak has been paid
+		if maxStreak > 0 && previous.Streak >= maxStreak {
+			streak = 1
+		}
+	}
+
+	var reward *models.AttendanceReward
+	for i := range rewards {
+		if rewards[i].StreakDays == streak {
+			reward = &rewards[i]
+			break
+		}
+	}
+
+	attendance := models.Attendance{
+		UserID:         user.ID,
+		IP:             clientIP,
+		DeviceID:       deviceID,
+		RequestCount:   1,
+		AttendanceDate: today,
+		Streak:         streak,
+	}
+	if reward != nil {
+		attendance.RewardType = reward.RewardType
+		attendance.RewardAmount = reward.Amount
+	}
+
+	tx := initializers.DB.Begin()
+
+	if err := tx.Create(&attendance).Error; err != nil {
+		tx.Rollback()
+		format_errors.ConflictError(c, fmt.Errorf("Already checked in today"))
+		return
+	}
+
+	if reward != nil && reward.Amount > 0 {
+		switch reward.RewardType {
+		case "point":
+			var profile models.Profile
+			if err := tx.Where("user_id = ?", user.ID).First(&profile).Error; err != nil {
+				tx.Rollback()
+				format_errors.InternalServerError(c, err)
+				return
+			}
+
+			if err := tx.Model(&profile).Update("point", profile.Point+int32(reward.Amount)).Error; err != nil {
+				tx.Rollback()
+				format_errors.InternalServerError(c, err)
+				return
+			}
+
+			transaction := models.Transaction{
+				UserID:        user.ID,
+				Type:          "attendanceReward",
+				Amount:        reward.Amount,
+				BalanceBefore: profile.Balance,
+				BalanceAfter:  profile.Balance,
+				PointBefore:   float64(profile.Point),
+				PointAfter:    float64(profile.Point) + reward.Amount,
+				Explation:     fmt.Sprintf("%d-day attendance streak", streak),
+				Status:        "A",
+				ApprovedAt:    now,
+			}
+			if err := tx.Create(&transaction).Error; err != nil {
+				tx.Rollback()
+				format_errors.InternalServerError(c, err)
+				return
+			}
+		}
+	}
+
+	if err := services.GrantAttendanceRouletteSpins(tx, user.ID, attendance.ID); err != nil {
+		tx.Rollback()
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	// Coupons are issued after the check-in so a campaign that can no longer issue does not
+	// cost the user the check-in and its streak
+	couponsIssued := 0
+	if reward != nil && reward.RewardType == "coupon" {
+		couponsIssued = issueAttendanceCoupons(user.ID, reward)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Attendance checked successfully",
+		"data": gin.H{
+			"attendance":    attendance,
+			"streak":        streak,
+			"reward":        reward,
+			"couponsIssued": couponsIssued,
+		},
+	})
+}
+
+// issueAttendanceCoupons issues the coupons of a milestone reward, Amount being their number,
+// and returns how many were issued. A failure leaves the rest unissued and alerts admins, who
+// can issue them by hand.
+func issueAttendanceCoupons(userID uint, reward *models.AttendanceReward) int {
+	if reward.CouponCampaignID == nil {
+		alertAttendanceCouponFailure(userID, reward, 0, fmt.Errorf("the reward has no coupon campaign"))
+		return 0
+	}
+
+	issued := 0
+	for i := 0; i < int(reward.Amount); i++ {
+		err := initializers.DB.Transaction(func(tx *gorm.DB) error {
+			var campaign models.CouponCampaign
+			if err := tx.First(&campaign, *reward.CouponCampaignID).Error; err != nil {
+				return err
+			}
+			_, err := services.IssueCoupon(tx, &campaign, userID, "attendance", nil)
+			return err
+		})
+		if err != nil {
+			alertAttendanceCouponFailure(userID, reward, issued, err)
+			break
+		}
+		issued++
+	}
+	return issued
+}
+
+// alertAttendanceCouponFailure reports the coupons of a milestone reward the user did not get
+func alertAttendanceCouponFailure(userID uint, reward *models.AttendanceReward, issued int, err error) {
+	fmt.Printf("Error issuing attendance coupon to user %d: %v\n", userID, err)
+
+	title := "Attendance Coupon Failed"
+	message := fmt.Sprintf("User ID %d reached a %d-day attendance streak but only %d of %d coupons were issued: %v",
+		userID, reward.StreakDays, issued, int(reward.Amount), err)
+	if _, err := services.CreateAlert(initializers.DB, "attendanceReward", title, message, userID, "/admin/coupons"); err != nil {
+		fmt.Printf("Error creating attendance coupon alert: %v\n", err)
+	}
+}
+
+// GetAttendanceCalendar returns the authenticated user's check-ins for a month (?month=YYYY-MM)
+func GetAttendanceCalendar(c *gin.Context) {
+	user, err := helpers.GetGinAuthUser(c)
+	if err != nil {
+		format_errors.UnauthorizedError(c, err)
+		return
+	}
+
+	config := getAttendanceConfig()
+	today := config.BusinessDay(time.Now())
+
+	month := c.DefaultQuery("month", today[:7])
+	monthStart, err := time.Parse("2006-01", month)
+	if err != nil {
+		format_errors.BadRequestError(c, fmt.Errorf("Invalid month, expected YYYY-MM"))
+		return
+	}
+	monthEnd := monthStart.AddDate(0, 1, -1)
+
+	var attendances []models.Attendance
+	if err := initializers.DB.
+		Where("user_id = ? AND attendance_date BETWEEN ? AND ?", user.ID, monthStart.Format("2006-01-02"), monthEnd.Format("2006-01-02")).
+		Order("attendance_date ASC").
+		Find(&attendances).Error; err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	// Current streak is the last check-in's streak if it is today or yesterday
+	currentStreak := 0
+	checkedInToday := false
+	var last models.Attendance
+	if err := initializers.DB.Where("user_id = ?", user.ID).Order("attendance_date DESC").First(&last).Error; err == nil {
+		todayDate, _ := time.Parse("2006-01-02", today)
+		yesterday := todayDate.AddDate(0, 0, -1).Format("2006-01-02")
+		if last.AttendanceDate == today {
+			currentStreak = last.Streak
+			checkedInToday = true
+		} else if last.AttendanceDate == yesterday {
+			currentStreak = last.Streak
+		}
+	}
+
+	var rewards []models.AttendanceReward
+	initializers.DB.Where("is_active = ?", true).Order("streak_days ASC").Find(&rewards)
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": gin.H{
+			"month":          month,
+			"today":          today,
+			"daysInMonth":    monthEnd.Day(),
+			"attendances":    attendances,
+			"currentStreak":  currentStreak,
+			"checkedInToday": checkedInToday,
+			"rewards":        rewards,
+		},
+	})
+}
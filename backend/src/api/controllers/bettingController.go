@@ -11,6 +11,7 @@ import (
 	"github.com/hotbrainy/go-betting/backend/db/initializers"
 	format_errors "github.com/hotbrainy/go-betting/backend/internal/format-errors"
 	"github.com/hotbrainy/go-betting/backend/internal/models"
+	"github.com/hotbrainy/go-betting/backend/internal/services"
 	"github.com/hotbrainy/go-betting/backend/internal/validations"
 	"gorm.io/gorm"
 )
@@ -150,250 +151,38 @@ func GetAllCasinoBetting(c *gin.Context) {
 		input.Limit = 25
 	}
 
-	// Build query with preloads - fetch both bet and win types
-	query := initializers.DB.Model(&models.CasinoBet{}).
-		Preload("User", func(db *gorm.DB) *gorm.DB {
-			return db.Preload("Profile").Preload("Root").Preload("Parent")
-		})
-
-	// Apply filters
-	if input.GameNameFilter != "" {
-		if input.GameNameFilter == "slot" {
-			query = query.Where("casino_bets.game_name LIKE ?", "%slot%")
-		} else if input.GameNameFilter == "not_slot" {
-			query = query.Where("casino_bets.game_name NOT LIKE ?", "%slot%")
-		}
-	}
-
-	// Don't filter by status here - we'll filter after consolidation
-	// Only filter by type if status is specifically looking for bet or win
-	if input.Status != "" && (input.Status == "bet" || input.Status == "win") {
-		query = query.Where("casino_bets.type = ?", input.Status)
-	}
-
-	if input.DateFrom != "" {
-		query = query.Where("casino_bets.created_at >= ?", input.DateFrom)
-	}
-
-	if input.DateTo != "" {
-		query = query.Where("casino_bets.created_at <= ?", input.DateTo)
-	}
+	query := services.CasinoRoundQuery(initializers.DB, services.CasinoRoundFilter{
+		GameNameFilter: input.GameNameFilter,
+		Status:         input.Status,
+		DateFrom:       input.DateFrom,
+		DateTo:         input.DateTo,
+		Search:         input.Search,
+	})
 
-	// Apply search filter (nickname, phone, or transaction ID)
-	if input.Search != "" {
-		searchPattern := "%" + input.Search + "%"
-		query = query.Joins("JOIN users ON users.id = casino_bets.user_id").
-			Joins("JOIN profiles ON profiles.user_id = users.id").
-			Where("CAST(casino_bets.id AS TEXT) LIKE ? OR casino_bets.trans_id LIKE ? OR profiles.nickname LIKE ? OR profiles.phone LIKE ?",
-				searchPattern, searchPattern, searchPattern, searchPattern)
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		format_errors.InternalServerError(c, err)
+		return
 	}
 
-	// Get all records (both bet and win) that match filters, ordered by user_id, betting_time, and created_at
-	var allRecords []models.CasinoBet
+	// Bets and wins are consolidated into rounds at ingest, so a page is read directly
+	var rounds []models.CasinoRound
 	if err := query.
-		Order("casino_bets.user_id ASC, casino_bets.betting_time ASC, casino_bets.created_at ASC").
-		Find(&allRecords).Error; err != nil {
+		Preload("User", func(db *gorm.DB) *gorm.DB {
+			return db.Preload("Profile").Preload("Root").Preload("Parent")
+		}).
+		Order("casino_rounds.created_at DESC, casino_rounds.id DESC").
+		Offset(input.Offset).
+		Limit(input.Limit).
+		Find(&rounds).Error; err != nil {
 		format_errors.InternalServerError(c, err)
 		return
 	}
 
-	// Consolidate bet/win pairs
-	type ConsolidatedBet struct {
-		ID           uint         `json:"id"`
-		UserID       uint         `json:"userId"`
-		User         *models.User `json:"user"`
-		GameID       uint         `json:"gameId"`
-		GameName     string       `json:"gameName"`
-		TransID      string       `json:"transId"`
-		BettingTime  uint         `json:"bettingTime"`
-		Details      interface{}  `json:"details"`
-		BetAmount    float64      `json:"betAmount"`    // Original bet amount (negative)
-		WinAmount    float64      `json:"winAmount"`    // Win amount (0 for loss, positive for win)
-		NetAmount    float64      `json:"netAmount"`    // Net change (winAmount - abs(betAmount))
-		ResultStatus string       `json:"resultStatus"` // "won" or "lost"
-		BeforeAmount float64      `json:"beforeAmount"` // Balance before bet
-		AfterAmount  float64      `json:"afterAmount"`  // Balance after win
-		Status       string       `json:"status"`       // Original status from bet record
-		CreatedAt    time.Time    `json:"createdAt"`    // Bet creation time
-		UpdatedAt    time.Time    `json:"updatedAt"`    // Win update time
-		BetID        uint         `json:"betId"`        // ID of bet record
-		WinID        uint         `json:"winId"`        // ID of win record
-	}
-
-	var consolidatedBets []ConsolidatedBet
-
-	// Group records by user_id and betting_time
-	type GroupKey struct {
-		UserID      uint
-		BettingTime uint
-	}
-	groups := make(map[GroupKey][]models.CasinoBet)
-
-	for _, record := range allRecords {
-		key := GroupKey{
-			UserID:      record.UserID,
-			BettingTime: record.BettingTime,
-		}
-		groups[key] = append(groups[key], record)
-	}
-
-	// Process each group to match bet/win pairs
-	for _, group := range groups {
-		// Separate bets and wins
-		var bets []models.CasinoBet
-		var wins []models.CasinoBet
-
-		for _, record := range group {
-			if record.Type == "bet" {
-				bets = append(bets, record)
-			} else if record.Type == "win" {
-				wins = append(wins, record)
-			}
-		}
-
-		// Sort bets and wins by created_at to ensure correct matching order
-		sort.Slice(bets, func(i, j int) bool {
-			return bets[i].CreatedAt.Before(bets[j].CreatedAt)
-		})
-		sort.Slice(wins, func(i, j int) bool {
-			return wins[i].CreatedAt.Before(wins[j].CreatedAt)
-		})
-
-		// Match bets with wins using the balance chain
-		// The after_amount of a bet should match the before_amount of its corresponding win
-		matchedBets := make(map[uint]bool)
-		matchedWins := make(map[uint]bool)
-
-		for i := range bets {
-			if matchedBets[bets[i].ID] {
-				continue
-			}
-
-			for j := range wins {
-				if matchedWins[wins[j].ID] {
-					continue
-				}
-
-				// Match if bet's after_amount equals win's before_amount
-				// Also check that they have the same user_id and betting_time (already grouped)
-				if bets[i].AfterAmount == wins[j].BeforeAmount {
-					// Found a match
-					betAmount := bets[i].Amount // This is negative
-					winAmount := wins[j].Amount // This is 0 for loss, positive for win
-
-					// Determine result status
-					resultStatus := "lost"
-					if winAmount > 0 {
-						resultStatus = "won"
-					}
-
-					// Calculate net amount
-					netAmount := winAmount + betAmount // betAmount is negative, so this is winAmount - abs(betAmount)
-
-					consolidated := ConsolidatedBet{
-						ID:           bets[i].ID, // Use bet ID as primary
-						UserID:       bets[i].UserID,
-						User:         bets[i].User,
-						GameID:       bets[i].GameID,
-						GameName:     bets[i].GameName,
-						TransID:      bets[i].TransID,
-						BettingTime:  bets[i].BettingTime,
-						Details:      wins[j].Details, // Use win record's details as it has the final/complete information
-						BetAmount:    betAmount,
-						WinAmount:    winAmount,
-						NetAmount:    netAmount,
-						ResultStatus: resultStatus,
-						BeforeAmount: bets[i].BeforeAmount,
-						AfterAmount:  wins[j].AfterAmount,
-						Status:       bets[i].Status,
-						CreatedAt:    bets[i].CreatedAt,
-						UpdatedAt:    wins[j].UpdatedAt,
-						BetID:        bets[i].ID,
-						WinID:        wins[j].ID,
-					}
-
-					consolidatedBets = append(consolidatedBets, consolidated)
-
-					matchedBets[bets[i].ID] = true
-					matchedWins[wins[j].ID] = true
-					break
-				}
-			}
-		}
-
-		// Handle unmatched bets (bets without corresponding wins - pending bets)
-		for i := range bets {
-			if !matchedBets[bets[i].ID] {
-				consolidated := ConsolidatedBet{
-					ID:           bets[i].ID,
-					UserID:       bets[i].UserID,
-					User:         bets[i].User,
-					GameID:       bets[i].GameID,
-					GameName:     bets[i].GameName,
-					TransID:      bets[i].TransID,
-					BettingTime:  bets[i].BettingTime,
-					Details:      bets[i].Details,
-					BetAmount:    bets[i].Amount,
-					WinAmount:    0,
-					NetAmount:    bets[i].Amount,
-					ResultStatus: "pending",
-					BeforeAmount: bets[i].BeforeAmount,
-					AfterAmount:  bets[i].AfterAmount,
-					Status:       bets[i].Status,
-					CreatedAt:    bets[i].CreatedAt,
-					UpdatedAt:    bets[i].UpdatedAt,
-					BetID:        bets[i].ID,
-					WinID:        0,
-				}
-				consolidatedBets = append(consolidatedBets, consolidated)
-			}
-		}
-	}
-
-	// Apply status filter after consolidation
-	if input.Status != "" && input.Status != "bet" && input.Status != "win" {
-		filteredBets := []ConsolidatedBet{}
-		for _, bet := range consolidatedBets {
-			if input.Status == "won" && bet.ResultStatus == "won" {
-				filteredBets = append(filteredBets, bet)
-			} else if input.Status == "lost" && bet.ResultStatus == "lost" {
-				filteredBets = append(filteredBets, bet)
-			} else if input.Status == "pending" && bet.ResultStatus == "pending" {
-				filteredBets = append(filteredBets, bet)
-			} else if input.Status == "cancelled" && bet.Status == "cancelled" {
-				filteredBets = append(filteredBets, bet)
-			}
-		}
-		consolidatedBets = filteredBets
-	}
-
-	// Sort by created_at DESC (most recent first)
-	sort.Slice(consolidatedBets, func(i, j int) bool {
-		return consolidatedBets[i].CreatedAt.After(consolidatedBets[j].CreatedAt)
-	})
-
-	// Get total count before pagination
-	total := int64(len(consolidatedBets))
-
-	// Apply pagination
-	start := input.Offset
-	end := input.Offset + input.Limit
-	if start > len(consolidatedBets) {
-		start = len(consolidatedBets)
-	}
-	if end > len(consolidatedBets) {
-		end = len(consolidatedBets)
-	}
-
-	var paginatedBets []ConsolidatedBet
-	if start < len(consolidatedBets) {
-		paginatedBets = consolidatedBets[start:end]
-	}
-
 	c.JSON(http.StatusOK, gin.H{
 		"message": "Casino Bets retrieved successfully",
 		"status":  true,
-		"data":    paginatedBets,
+		"data":    rounds,
 		"total":   total,
 	})
 }
@@ -728,7 +517,7 @@ func GetUserBettingHistoryV2(c *gin.Context) {
 	// Build query for casino bets (not slot) with preloads
 	casinoQuery := initializers.DB.Model(&models.CasinoBet{}).
 		Where("user_id = ?", input.UserID).
-		Where("game_name NOT LIKE ?", "%slot%")
+		Where("game_type <> ?", services.CasinoGameTypeSlot)
 
 	// Apply filters for casino bets
 	if input.Status != "" && input.Status != "entire" {
@@ -764,7 +553,7 @@ func GetUserBettingHistoryV2(c *gin.Context) {
 	// Build query for slot bets with preloads
 	slotQuery := initializers.DB.Model(&models.CasinoBet{}).
 		Where("user_id = ?", input.UserID).
-		Where("game_name LIKE ?", "%slot%")
+		Where("game_type = ?", services.CasinoGameTypeSlot)
 
 	// Apply filters for slot bets
 	if input.Status != "" && input.Status != "entire" {
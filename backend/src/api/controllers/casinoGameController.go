@@ -0,0 +1,274 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hotbrainy/go-betting/backend/db/initializers"
+	format_errors "github.com/hotbrainy/go-betting/backend/internal/format-errors"
+	"github.com/hotbrainy/go-betting/backend/internal/helpers"
+	"github.com/hotbrainy/go-betting/backend/internal/models"
+	"github.com/hotbrainy/go-betting/backend/internal/services"
+	"gorm.io/gorm"
+)
+
+// casinoGameFilter reads the catalog filters of the query string
+func casinoGameFilter(c *gin.Context) services.CasinoGameFilter {
+	return services.CasinoGameFilter{
+		Vendor:       c.Query("vendor"),
+		Type:         c.Query("type"),
+		Tag:          c.Query("tag"),
+		Search:       strings.TrimSpace(c.Query("q")),
+		FeaturedOnly: c.Query("featured") == "true",
+	}
+}
+
+// GetCasinoGames searches the lobby catalog
+func GetCasinoGames(c *gin.Context) {
+	filter := casinoGameFilter(c)
+	filter.Visible = true
+	query := services.CasinoGameQuery(initializers.DB, filter)
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if page < 1 {
+		page = 1
+	}
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("pageSize", "40"))
+	if pageSize < 1 || pageSize > 200 {
+		pageSize = 40
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	var games []models.CasinoGame
+	if err := query.Order(services.CasinoGameLobbyOrder).Offset((page - 1) * pageSize).Limit(pageSize).Find(&games).Error; err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":  true,
+		"data":     games,
+		"total":    total,
+		"page":     page,
+		"pageSize": pageSize,
+	})
+}
+
+// GetCasinoGameCategories returns the game types, vendors and tags of the lobby catalog with
+// their game counts
+func GetCasinoGameCategories(c *gin.Context) {
+	visible := func() *gorm.DB {
+		return services.CasinoGameQuery(initializers.DB, services.CasinoGameFilter{Visible: true})
+	}
+
+	type typeCount struct {
+		Type  string `json:"type"`
+		Count int64  `json:"count"`
+	}
+	var types []typeCount
+	if err := visible().Select("type, COUNT(*) AS count").Group("type").Order("type").
+		Scan(&types).Error; err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	type vendorCount struct {
+		Vendor string `json:"vendor"`
+		Type   string `json:"type"`
+		Count  int64  `json:"count"`
+	}
+	var vendors []vendorCount
+	if err := visible().Select("vendor, type, COUNT(*) AS count").Group("vendor, type").Order("vendor, type").
+		Scan(&vendors).Error; err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	var tagLists []string
+	if err := visible().Where("tags <> ''").Pluck("tags", &tagLists).Error; err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+	tagCounts := map[string]int64{}
+	for _, list := range tagLists {
+		for _, tag := range strings.Split(list, ",") {
+			tagCounts[tag]++
+		}
+	}
+	type tagCount struct {
+		Tag   string `json:"tag"`
+		Count int64  `json:"count"`
+	}
+	tags := make([]tagCount, 0, len(tagCounts))
+	for tag, count := range tagCounts {
+		tags = append(tags, tagCount{Tag: tag, Count: count})
+	}
+	sort.Slice(tags, func(i, j int) bool { return tags[i].Tag < tags[j].Tag })
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": gin.H{
+			"types":   types,
+			"vendors": vendors,
+			"tags":    tags,
+		},
+	})
+}
+
+// GetMyCasinoFavorites lists the games the user starred, newest first
+func GetMyCasinoFavorites(c *gin.Context) {
+	user, err := helpers.GetGinAuthUser(c)
+	if err != nil {
+		format_errors.UnauthorizedError(c, err)
+		return
+	}
+
+	var favorites []models.CasinoGameFavorite
+	if err := initializers.DB.
+		Joins("CasinoGame").
+		Where("casino_game_favorites.user_id = ?", user.ID).
+		Where(`"CasinoGame".enabled = ? AND "CasinoGame".available = ?`, true, true).
+		Order("casino_game_favorites.created_at DESC").
+		Find(&favorites).Error; err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    favorites,
+	})
+}
+
+// AddCasinoFavorite stars a game
+func AddCasinoFavorite(c *gin.Context) {
+	user, err := helpers.GetGinAuthUser(c)
+	if err != nil {
+		format_errors.UnauthorizedError(c, err)
+		return
+	}
+
+	gameID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		format_errors.BadRequestError(c, err)
+		return
+	}
+
+	favorite, err := services.AddCasinoGameFavorite(initializers.DB, user.ID, uint(gameID))
+	if err != nil {
+		if errors.Is(err, services.ErrCasinoGameNotFound) {
+			format_errors.NotFound(c, err)
+			return
+		}
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    favorite,
+	})
+}
+
+// RemoveCasinoFavorite unstars a game
+func RemoveCasinoFavorite(c *gin.Context) {
+	user, err := helpers.GetGinAuthUser(c)
+	if err != nil {
+		format_errors.UnauthorizedError(c, err)
+		return
+	}
+
+	gameID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		format_errors.BadRequestError(c, err)
+		return
+	}
+
+	if err := services.RemoveCasinoGameFavorite(initializers.DB, user.ID, uint(gameID)); err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+	})
+}
+
+// GetMyRecentCasinoGames lists the games the user launched last
+func GetMyRecentCasinoGames(c *gin.Context) {
+	user, err := helpers.GetGinAuthUser(c)
+	if err != nil {
+		format_errors.UnauthorizedError(c, err)
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if limit < 1 || limit > 50 {
+		limit = 20
+	}
+
+	var plays []models.CasinoGamePlay
+	if err := initializers.DB.
+		Joins("CasinoGame").
+		Where("casino_game_plays.user_id = ?", user.ID).
+		Where(`"CasinoGame".enabled = ? AND "CasinoGame".available = ?`, true, true).
+		Order("casino_game_plays.played_at DESC").
+		Limit(limit).
+		Find(&plays).Error; err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    plays,
+	})
+}
+
+// GetCasinoVendors lists the vendors with an admin record and whether they can be played now,
+// with the message to show for those that cannot
+func GetCasinoVendors(c *gin.Context) {
+	var vendors []models.CasinoVendor
+	if err := initializers.DB.Order("order_num ASC, id ASC").Find(&vendors).Error; err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	now := time.Now()
+	data := make([]gin.H, 0, len(vendors))
+	for _, vendor := range vendors {
+		maintenance := vendor.InMaintenance(now)
+		item := gin.H{
+			"vendor":      vendor.Vendor,
+			"name":        vendor.Name,
+			"category":    vendor.Category,
+			"available":   vendor.Enabled && !maintenance,
+			"maintenance": maintenance,
+		}
+		if !vendor.Enabled || maintenance {
+			item["message"] = vendor.MaintenanceMessage
+			item["maintenanceEnd"] = vendor.MaintenanceEnd
+		} else if vendor.MaintenanceStart != nil && vendor.MaintenanceStart.After(now) {
+			// Announce upcoming maintenance
+			item["maintenanceStart"] = vendor.MaintenanceStart
+			item["maintenanceEnd"] = vendor.MaintenanceEnd
+			item["message"] = vendor.MaintenanceMessage
+		}
+		data = append(data, item)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    data,
+	})
+}
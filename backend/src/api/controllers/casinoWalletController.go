@@ -0,0 +1,167 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/hotbrainy/go-betting/backend/db/initializers"
+	format_errors "github.com/hotbrainy/go-betting/backend/internal/format-errors"
+	"github.com/hotbrainy/go-betting/backend/internal/services"
+	"github.com/hotbrainy/go-betting/backend/internal/validations"
+)
+
+// defaultCasinoWalletProvider is assumed when a callback does not name its aggregator
+const defaultCasinoWalletProvider = "honorlink"
+
+type casinoWalletInput struct {
+	Provider      string  `json:"provider" binding:"max=50"`
+	Username      string  `json:"username" binding:"required"`
+	TransactionID string  `json:"transactionId" binding:"required,max=100"`
+	RoundID       string  `json:"roundId" binding:"max=100"`
+	Vendor        string  `json:"vendor" binding:"max=100"`
+	GameType      string  `json:"gameType" binding:"max=50"`
+	GameID        string  `json:"gameId" binding:"max=100"`
+	Amount        float64 `json:"amount" binding:"min=0"`
+	ReferenceID   string  `json:"referenceId" binding:"max=100"`
+}
+
+// requireSeamlessWallet rejects wallet callbacks while casino play uses balance transfers
+func requireSeamlessWallet(c *gin.Context) bool {
+	if !services.CasinoWalletSeamless() {
+		format_errors.ForbbidenError(c, errors.New("seamless wallet is disabled"))
+		return false
+	}
+	return true
+}
+
+func respondCasinoWalletError(c *gin.Context, err error) {
+	var limitErr *services.CasinoLimitError
+	switch {
+	case errors.As(err, &limitErr):
+		services.AlertCasinoLimitBreach(initializers.DB, limitErr, "rejected")
+		c.JSON(http.StatusForbidden, gin.H{
+			"success": false,
+			"code":    "LIMIT_EXCEEDED",
+			"error":   err.Error(),
+		})
+	case errors.Is(err, services.ErrCasinoWalletUser):
+		format_errors.NotFound(c, err)
+	case errors.Is(err, services.ErrCasinoWalletFunds):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"code":    "INSUFFICIENT_FUNDS",
+			"error":   err.Error(),
+		})
+	case errors.Is(err, services.ErrCasinoWalletAmount):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"code":    "INVALID_AMOUNT",
+			"error":   err.Error(),
+		})
+	case errors.Is(err, services.ErrCasinoWalletDuplicateTx):
+		format_errors.ConflictError(c, err)
+	default:
+		format_errors.InternalServerError(c, err)
+	}
+}
+
+// CasinoWalletBalance answers the aggregator's balance callback
+func CasinoWalletBalance(c *gin.Context) {
+	if !requireSeamlessWallet(c) {
+		return
+	}
+
+	var userInput struct {
+		Username string `json:"username" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&userInput); err != nil {
+		if errs, ok := err.(validator.ValidationErrors); ok {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{
+				"validations": validations.FormatValidationErrors(errs),
+			})
+			return
+		}
+		format_errors.BadRequestError(c, err)
+		return
+	}
+
+	balance, err := services.CasinoWalletBalance(initializers.DB, userInput.Username)
+	if err != nil {
+		respondCasinoWalletError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":  true,
+		"username": userInput.Username,
+		"balance":  balance,
+	})
+}
+
+// CasinoWalletDebit takes a casino stake from the user's balance
+func CasinoWalletDebit(c *gin.Context) {
+	handleCasinoWalletTransaction(c, "debit")
+}
+
+// CasinoWalletCredit pays a casino win into the user's balance
+func CasinoWalletCredit(c *gin.Context) {
+	handleCasinoWalletTransaction(c, "credit")
+}
+
+// CasinoWalletRollback undoes an earlier debit or credit
+func CasinoWalletRollback(c *gin.Context) {
+	handleCasinoWalletTransaction(c, "rollback")
+}
+
+func handleCasinoWalletTransaction(c *gin.Context, action string) {
+	if !requireSeamlessWallet(c) {
+		return
+	}
+
+	var userInput casinoWalletInput
+	if err := c.ShouldBindJSON(&userInput); err != nil {
+		if errs, ok := err.(validator.ValidationErrors); ok {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{
+				"validations": validations.FormatValidationErrors(errs),
+			})
+			return
+		}
+		format_errors.BadRequestError(c, err)
+		return
+	}
+
+	if action == "rollback" && userInput.ReferenceID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "referenceId is required for rollbacks",
+		})
+		return
+	}
+	if userInput.Provider == "" {
+		userInput.Provider = defaultCasinoWalletProvider
+	}
+
+	walletTx, err := services.ApplyCasinoWalletTransaction(initializers.DB, action, services.CasinoWalletRequest{
+		Provider:      userInput.Provider,
+		TransactionID: userInput.TransactionID,
+		Username:      userInput.Username,
+		RoundID:       userInput.RoundID,
+		Vendor:        userInput.Vendor,
+		GameType:      userInput.GameType,
+		GameID:        userInput.GameID,
+		Amount:        userInput.Amount,
+		ReferenceID:   userInput.ReferenceID,
+	})
+	if err != nil {
+		respondCasinoWalletError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":       true,
+		"username":      userInput.Username,
+		"transactionId": walletTx.TransactionID,
+		"balance":       walletTx.BalanceAfter,
+	})
+}
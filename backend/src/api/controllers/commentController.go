@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"fmt"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -9,6 +10,7 @@ import (
 	format_errors "github.com/hotbrainy/go-betting/backend/internal/format-errors"
 	"github.com/hotbrainy/go-betting/backend/internal/helpers"
 	"github.com/hotbrainy/go-betting/backend/internal/models"
+	"github.com/hotbrainy/go-betting/backend/internal/services"
 	"github.com/hotbrainy/go-betting/backend/internal/validations"
 )
 
@@ -52,15 +54,34 @@ func CommentOnPost(c *gin.Context) {
 		format_errors.InternalServerError(c, err)
 		return
 	}
+
+	if authUser.WhiteCommentOnPost == "2" {
+		format_errors.ForbbidenError(c, fmt.Errorf("You are not allowed to comment on posts"))
+		return
+	}
+
 	comment := models.Comment{
 		PostID: userInput.PostId,
 		UserID: authUser.ID,
 		Body:   userInput.Body,
 	}
 
-	result := initializers.DB.Create(&comment)
+	tx := initializers.DB.Begin()
 
-	if err := result.Error; err != nil {
+	if err := tx.Create(&comment).Error; err != nil {
+		tx.Rollback()
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	award, err := services.AwardPostPoints(tx, authUser, comment.PostID, &comment.ID)
+	if err != nil {
+		tx.Rollback()
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	if err := tx.Commit().Error; err != nil {
 		format_errors.InternalServerError(c, err)
 		return
 	}
@@ -68,6 +89,7 @@ func CommentOnPost(c *gin.Context) {
 	// Return the comment
 	c.JSON(http.StatusOK, gin.H{
 		"comment": comment,
+		"award":   award,
 	})
 }
 
@@ -0,0 +1,182 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/hotbrainy/go-betting/backend/db/initializers"
+	format_errors "github.com/hotbrainy/go-betting/backend/internal/format-errors"
+	"github.com/hotbrainy/go-betting/backend/internal/helpers"
+	"github.com/hotbrainy/go-betting/backend/internal/models"
+	"github.com/hotbrainy/go-betting/backend/internal/services"
+	"github.com/hotbrainy/go-betting/backend/internal/validations"
+	"gorm.io/gorm"
+)
+
+// GetMyCoupons lists the authenticated user's coupons, optionally filtered by status
+func GetMyCoupons(c *gin.Context) {
+	user, err := helpers.GetGinAuthUser(c)
+	if err != nil {
+		format_errors.UnauthorizedError(c, err)
+		return
+	}
+
+	query := initializers.DB.Model(&models.UserCoupon{}).
+		Preload("Campaign").
+		Where("user_id = ?", user.ID)
+
+	if status := c.Query("status"); status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var coupons []models.UserCoupon
+	if err := query.Order("created_at DESC").Find(&coupons).Error; err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    coupons,
+		"count":   len(coupons),
+	})
+}
+
+// ClaimCoupon issues a coupon to the authenticated user by campaign code
+func ClaimCoupon(c *gin.Context) {
+	user, err := helpers.GetGinAuthUser(c)
+	if err != nil {
+		format_errors.UnauthorizedError(c, err)
+		return
+	}
+
+	var userInput struct {
+		Code string `json:"code" binding:"required,min=1,max=50"`
+	}
+
+	if err := c.ShouldBindJSON(&userInput); err != nil {
+		if errs, ok := err.(validator.ValidationErrors); ok {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{
+				"validations": validations.FormatValidationErrors(errs),
+			})
+			return
+		}
+		format_errors.BadRequestError(c, err)
+		return
+	}
+
+	tx := initializers.DB.Begin()
+
+	var campaign models.CouponCampaign
+	if err := tx.Where("code = ?", userInput.Code).First(&campaign).Error; err != nil {
+		tx.Rollback()
+		format_errors.NotFound(c, err, "The coupon code is invalid")
+		return
+	}
+
+	coupon, err := services.IssueCoupon(tx, &campaign, user.ID, "code", nil)
+	if err != nil {
+		tx.Rollback()
+		respondCouponError(c, err)
+		return
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Coupon claimed successfully",
+		"data":    coupon,
+	})
+}
+
+// RedeemCoupon redeems one of the authenticated user's issued coupons
+func RedeemCoupon(c *gin.Context) {
+	user, err := helpers.GetGinAuthUser(c)
+	if err != nil {
+		format_errors.UnauthorizedError(c, err)
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		format_errors.BadRequestError(c, err)
+		return
+	}
+
+	tx := initializers.DB.Begin()
+
+	var coupon models.UserCoupon
+	if err := tx.Where("id = ? AND user_id = ?", id, user.ID).First(&coupon).Error; err != nil {
+		tx.Rollback()
+		format_errors.NotFound(c, err)
+		return
+	}
+
+	redemption, err := services.RedeemCoupon(tx, &coupon)
+	if err != nil {
+		// Keep the expired status so the coupon is no longer offered
+		if errors.Is(err, services.ErrCouponExpired) {
+			tx.Commit()
+		} else {
+			tx.Rollback()
+		}
+		respondCouponError(c, err)
+		return
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Coupon redeemed successfully",
+		"data":    redemption,
+	})
+}
+
+// GetMyCouponRedemptions returns the authenticated user's redemption history
+func GetMyCouponRedemptions(c *gin.Context) {
+	user, err := helpers.GetGinAuthUser(c)
+	if err != nil {
+		format_errors.UnauthorizedError(c, err)
+		return
+	}
+
+	var redemptions []models.CouponRedemption
+	if err := initializers.DB.Where("user_id = ?", user.ID).Order("created_at DESC").Find(&redemptions).Error; err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    redemptions,
+	})
+}
+
+// respondCouponError maps coupon service errors to responses
+func respondCouponError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		format_errors.NotFound(c, err)
+	case errors.Is(err, services.ErrCouponInactive),
+		errors.Is(err, services.ErrCouponExpired),
+		errors.Is(err, services.ErrCouponLevel),
+		errors.Is(err, services.ErrCouponUserLimit),
+		errors.Is(err, services.ErrCouponTotalLimit),
+		errors.Is(err, services.ErrCouponNotRedeemable),
+		errors.Is(err, services.ErrCouponNoDeposit):
+		format_errors.BadRequestError(c, err)
+	default:
+		format_errors.InternalServerError(c, err)
+	}
+}
@@ -0,0 +1,32 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hotbrainy/go-betting/backend/db/initializers"
+	"github.com/hotbrainy/go-betting/backend/internal/services"
+)
+
+// honorLinkMaxLagSeconds is the sync lag past which the health check fails
+const honorLinkMaxLagSeconds = 10 * 60
+
+// GetHonorLinkSyncHealth reports the HonorLink sync lag for monitoring, answering 503 when the
+// sync has not run yet or trails by more than honorLinkMaxLagSeconds. The endpoint is public, so
+// sync errors are left to AdminGetHonorLinkSync.
+func GetHonorLinkSyncHealth(c *gin.Context) {
+	health := services.GetHonorLinkSyncHealth(initializers.DB)
+
+	status := http.StatusOK
+	state := "ok"
+	if health.LagSeconds < 0 || health.LagSeconds > honorLinkMaxLagSeconds {
+		status = http.StatusServiceUnavailable
+		state = "lagging"
+	}
+
+	c.JSON(status, gin.H{
+		"success":    status == http.StatusOK,
+		"status":     state,
+		"lagSeconds": health.LagSeconds,
+	})
+}
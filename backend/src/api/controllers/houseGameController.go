@@ -0,0 +1,114 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hotbrainy/go-betting/backend/db/initializers"
+	format_errors "github.com/hotbrainy/go-betting/backend/internal/format-errors"
+	"github.com/hotbrainy/go-betting/backend/internal/minigame"
+	"github.com/hotbrainy/go-betting/backend/internal/models"
+	"github.com/hotbrainy/go-betting/backend/internal/services"
+)
+
+func respondHouseGameError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrHouseGameUnknown),
+		errors.Is(err, services.ErrHouseRoundNotDrawn),
+		errors.Is(err, services.ErrHouseSeedNotPresent):
+		format_errors.BadRequestError(c, err)
+	default:
+		format_errors.InternalServerError(c, err)
+	}
+}
+
+// GetHouseCommitment returns the seed hash committed for the running round of an
+// in-house game (?gameType=house3min)
+func GetHouseCommitment(c *gin.Context) {
+	round, err := minigame.CurrentRound(c.Request.Context(), c.Query("gameType"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid game type",
+		})
+		return
+	}
+
+	seed, err := services.HouseRoundSeed(initializers.DB, round)
+	if err != nil {
+		respondHouseGameError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": gin.H{
+			"round":          round,
+			"serverSeedHash": seed.ServerSeedHash,
+			"message":        minigame.HouseDrawMessage(round.GameType, round.Date, round.Round),
+		},
+	})
+}
+
+// VerifyHouseRound reveals the seed of a drawn in-house round and recomputes its draw
+// (?gameType=house3min&date=2006-01-02&round=1)
+func VerifyHouseRound(c *gin.Context) {
+	gameType := c.Query("gameType")
+	if !minigame.HouseGameTypes[gameType] {
+		respondHouseGameError(c, services.ErrHouseGameUnknown)
+		return
+	}
+
+	number, err := strconv.ParseUint(c.Query("round"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid round",
+		})
+		return
+	}
+
+	round, err := minigame.RoundByNumber(gameType, c.Query("date"), uint(number))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	if time.Now().Before(round.DrawAt) {
+		respondHouseGameError(c, services.ErrHouseRoundNotDrawn)
+		return
+	}
+
+	seed, draw, err := services.DrawHouseRound(initializers.DB, round)
+	if err != nil {
+		respondHouseGameError(c, err)
+		return
+	}
+
+	// Compare against the draw that bets were settled with
+	var stored models.MiniGameDraw
+	var storedDraw *models.MiniGameDraw
+	if err := initializers.DB.Where("game_type = ? AND draw_date = ? AND round = ?", gameType, round.Date, round.Round).First(&stored).Error; err == nil {
+		storedDraw = &stored
+	}
+
+	verified := storedDraw != nil &&
+		stored.Ball1 == draw.Balls[0] && stored.Ball2 == draw.Balls[1] && stored.Ball3 == draw.Balls[2] &&
+		stored.Ball4 == draw.Balls[3] && stored.Ball5 == draw.Balls[4] && stored.PowerBall == draw.PowerBall
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": gin.H{
+			"round":          round,
+			"serverSeed":     seed.ServerSeed,
+			"serverSeedHash": seed.ServerSeedHash,
+			"revealedAt":     seed.RevealedAt,
+			"message":        minigame.HouseDrawMessage(gameType, round.Date, round.Round),
+			"draw":           draw,
+			"storedDraw":     storedDraw,
+			"verified":       verified,
+		},
+	})
+}
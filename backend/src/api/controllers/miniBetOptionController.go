@@ -2,6 +2,7 @@ package controllers
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -13,8 +14,11 @@ import (
 	"github.com/hotbrainy/go-betting/backend/db/initializers"
 	format_errors "github.com/hotbrainy/go-betting/backend/internal/format-errors"
 	"github.com/hotbrainy/go-betting/backend/internal/helpers"
+	"github.com/hotbrainy/go-betting/backend/internal/minigame"
 	"github.com/hotbrainy/go-betting/backend/internal/models"
+	"github.com/hotbrainy/go-betting/backend/internal/services"
 	"github.com/hotbrainy/go-betting/backend/internal/validations"
+	"gorm.io/gorm"
 )
 
 // CreateMiniBetOption creates a new mini bet option
@@ -133,6 +137,11 @@ func GetMiniBetOptions(c *gin.Context) {
 		return
 	}
 
+	if err := services.ApplyEffectiveMiniOdds(initializers.DB, miniBetOptions); err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
 		"data":    miniBetOptions,
@@ -152,6 +161,13 @@ func GetMiniBetOption(c *gin.Context) {
 		return
 	}
 
+	odds, err := services.EffectiveMiniBetOdds(initializers.DB, &miniBetOption)
+	if err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+	miniBetOption.Odds = odds
+
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
 		"data":    miniBetOption,
@@ -218,7 +234,6 @@ func UpdateMiniBetOption(c *gin.Context) {
 
 	// Update the mini bet option
 	miniBetOption.Name = userInput.Name
-	miniBetOption.Odds = userInput.Odds
 	miniBetOption.Type = userInput.Type
 	miniBetOption.Ball = userInput.Ball
 	miniBetOption.Text = userInput.Text
@@ -229,12 +244,23 @@ func UpdateMiniBetOption(c *gin.Context) {
 	miniBetOption.Enabled = userInput.Enabled
 	miniBetOption.OrderNum = userInput.OrderNum
 
-	result = initializers.DB.Save(&miniBetOption)
-
-	if err := result.Error; err != nil {
+	// Odds change through a new odds version so that placed bets keep the version they used
+	tx := initializers.DB.Begin()
+	if err := tx.Save(&miniBetOption).Error; err != nil {
+		tx.Rollback()
 		format_errors.InternalServerError(c, err)
 		return
 	}
+	if err := versionMiniOddsNow(c, tx, miniBetOption.GameType, map[uint]string{miniBetOption.ID: userInput.Odds}); err != nil {
+		tx.Rollback()
+		respondMiniBetError(c, err)
+		return
+	}
+	if err := tx.Commit().Error; err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+	miniBetOption.Odds = userInput.Odds
 
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
@@ -321,21 +347,44 @@ func BulkUpdateMiniBetOptions(c *gin.Context) {
 		return
 	}
 
-	// Update each option
+	// Update each option, collecting odds changes per game type
+	tx := initializers.DB.Begin()
+	oddsChanges := map[string]map[uint]string{}
 	for _, option := range userInput.Options {
 		var miniBetOption models.MiniBetOption
-		result := initializers.DB.First(&miniBetOption, option.ID)
+		result := tx.First(&miniBetOption, option.ID)
 		if err := result.Error; err != nil {
 			continue // Skip if not found
 		}
 
 		miniBetOption.Enabled = option.Enabled
 		if option.Odds != "" {
-			miniBetOption.Odds = option.Odds
+			if oddsChanges[miniBetOption.GameType] == nil {
+				oddsChanges[miniBetOption.GameType] = map[uint]string{}
+			}
+			oddsChanges[miniBetOption.GameType][miniBetOption.ID] = option.Odds
 		}
 		miniBetOption.OrderNum = option.OrderNum
 
-		initializers.DB.Save(&miniBetOption)
+		if err := tx.Save(&miniBetOption).Error; err != nil {
+			tx.Rollback()
+			format_errors.InternalServerError(c, err)
+			return
+		}
+	}
+
+	// One odds version per game type
+	for gameType, odds := range oddsChanges {
+		if err := versionMiniOddsNow(c, tx, gameType, odds); err != nil {
+			tx.Rollback()
+			respondMiniBetError(c, err)
+			return
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		format_errors.InternalServerError(c, err)
+		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
@@ -442,13 +491,12 @@ func PlaceMiniBet(c *gin.Context) {
 		return
 	}
 
+	// Odds and category come from the bet option; any sent by the client are ignored
 	var betInput struct {
 		GameType    string `json:"gameType" binding:"required"`
-		Category    string `json:"category" binding:"required"`
 		Pick        string `json:"pick" binding:"required"`
-		Odds        string `json:"odds" binding:"required"`
 		Amount      string `json:"amount" binding:"required"`
-		BetOptionID uint   `json:"betOptionId"` // ID of the MiniBetOption
+		BetOptionID uint   `json:"betOptionId" binding:"required"` // ID of the MiniBetOption
 	}
 
 	if err := c.ShouldBindJSON(&betInput); err != nil {
@@ -465,15 +513,6 @@ func PlaceMiniBet(c *gin.Context) {
 		return
 	}
 
-	// Parse odds to float64
-	odds, err := strconv.ParseFloat(betInput.Odds, 64)
-	if err != nil || odds <= 0 {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": "Invalid odds value",
-		})
-		return
-	}
-
 	// Parse amount to float64
 	amount, err := strconv.ParseFloat(betInput.Amount, 64)
 	if err != nil || amount <= 0 {
@@ -483,142 +522,28 @@ func PlaceMiniBet(c *gin.Context) {
 		return
 	}
 
-	// Fetch game distribution to get current round
-	client := &http.Client{}
-	req, err := http.NewRequest("GET", "https://ntry.com/data/json/games/dist.json", nil)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to fetch game distribution",
-		})
-		return
-	}
-
-	resp, err := client.Do(req)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to fetch game distribution",
-		})
-		return
-	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
+	// Resolve the round from the round clock; bets after the cutoff are rejected
+	round, err := minigame.CurrentRound(c.Request.Context(), betInput.GameType)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to read game distribution data",
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid game type",
 		})
 		return
 	}
-
-	var gameData map[string]interface{}
-	err = json.Unmarshal(body, &gameData)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to parse game distribution data",
+	if !round.IsOpen(time.Now()) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": fmt.Sprintf("Betting for round %d is closed", round.Round),
+			"round": round,
 		})
 		return
 	}
+	currentRound := round.Round
 
-	// Map internal game types to result JSON API URLs
-	resultURLMap := map[string]string{
-		"eos1min": "https://ntry.com/data/json/games/eos_powerball/1min/result.json",
-		"eos2min": "https://ntry.com/data/json/games/eos_powerball/2min/result.json",
-		"eos3min": "https://ntry.com/data/json/games/eos_powerball/3min/result.json",
-		"eos4min": "https://ntry.com/data/json/games/eos_powerball/4min/result.json",
-		"eos5min": "https://ntry.com/data/json/games/eos_powerball/5min/result.json",
-	}
-
-	// Get the result URL for the game type
-	resultURL := resultURLMap[betInput.GameType]
-	var currentRound uint
-
-	// Fetch from result.json to get current date_round
-	if resultURL != "" {
-		resultReq, err := http.NewRequest("GET", resultURL, nil)
-		if err == nil {
-			if resultResp, err := client.Do(resultReq); err == nil {
-				defer resultResp.Body.Close()
-				resultBody, err := io.ReadAll(resultResp.Body)
-				if err == nil {
-					var resultData map[string]interface{}
-					if err := json.Unmarshal(resultBody, &resultData); err == nil {
-						if dateRound, ok := resultData["date_round"]; ok {
-							// Parse date_round which can be int or string
-							switch v := dateRound.(type) {
-							case float64:
-								currentRound = uint(v)
-							case int:
-								currentRound = uint(v)
-							case string:
-								// Parse string to int, removing leading zeros (e.g., "092" -> 92, "0122" -> 122)
-								if parsed, parseErr := strconv.ParseUint(v, 10, 64); parseErr == nil {
-									currentRound = uint(parsed)
-								} else {
-									currentRound = uint(time.Now().Unix())
-								}
-							default:
-								currentRound = uint(time.Now().Unix())
-							}
-						} else {
-							currentRound = uint(time.Now().Unix())
-						}
-					} else {
-						currentRound = uint(time.Now().Unix())
-					}
-				} else {
-					currentRound = uint(time.Now().Unix())
-				}
-			} else {
-				currentRound = uint(time.Now().Unix())
-			}
-		} else {
-			currentRound = uint(time.Now().Unix())
-		}
-	} else {
-		// Fallback to dist.json for other game types
-		// Map internal game types to API keys
-		gameTypeMap := map[string]string{
-			"eos1min": "eos_powerball_1",
-			"eos2min": "eos_powerball_2",
-			"eos3min": "eos_powerball_3",
-			"eos4min": "eos_powerball_4",
-			"eos5min": "eos_powerball_5",
-		}
-
-		// Get the API key for the game type
-		apiKey := gameTypeMap[betInput.GameType]
-		if apiKey == "" {
-			apiKey = betInput.GameType
-		}
-
-		// Try to get round from game distribution data (field name is "rd" not "round")
-		if gameInfo, exists := gameData[apiKey].(map[string]interface{}); exists {
-			if roundValue, ok := gameInfo["rd"]; ok {
-				// Handle different possible types for round value
-				switch v := roundValue.(type) {
-				case float64:
-					currentRound = uint(v)
-				case int:
-					currentRound = uint(v)
-				case string:
-					// Try to parse string to uint
-					if parsed, parseErr := strconv.ParseUint(v, 10, 64); parseErr == nil {
-						currentRound = uint(parsed)
-					} else {
-						// If parsing fails, use timestamp as round
-						currentRound = uint(time.Now().Unix())
-					}
-				default:
-					// Use timestamp as fallback round
-					currentRound = uint(time.Now().Unix())
-				}
-			} else {
-				// No round found in game data, use timestamp
-				currentRound = uint(time.Now().Unix())
-			}
-		} else {
-			// Game type not found, use timestamp as fallback
-			currentRound = uint(time.Now().Unix())
+	// In-house rounds must be committed before any bet is taken on them
+	if minigame.HouseGameTypes[betInput.GameType] {
+		if _, err := services.HouseRoundSeed(initializers.DB, round); err != nil {
+			format_errors.InternalServerError(c, err)
+			return
 		}
 	}
 
@@ -636,10 +561,22 @@ func PlaceMiniBet(c *gin.Context) {
 		return
 	}
 
+	// Resolve the option for the user's level and snapshot its odds onto the bet
+	betOption, odds, oddsVersionID, err := services.ResolveMiniBetOption(initializers.DB, betInput.GameType, int(profile.Level), betInput.BetOptionID)
+	if err != nil {
+		respondMiniBetError(c, err)
+		return
+	}
+
+	if err := services.CheckMiniBetLimits(initializers.DB, betInput.GameType, int(profile.Level), amount); err != nil {
+		respondMiniBetError(c, err)
+		return
+	}
+
 	// Create the bet record
 	powerballBet := models.PowerballHistory{
 		GameType:      betInput.GameType,
-		Category:      betInput.Category,
+		Category:      betOption.Category,
 		UserID:        user.ID,
 		Amount:        amount,
 		Odds:          odds,
@@ -647,23 +584,32 @@ func PlaceMiniBet(c *gin.Context) {
 		Result:        "pending",
 		Status:        "pending",
 		Round:         currentRound,
-		BetOptionID:   &betInput.BetOptionID,
+		BetOptionID:   &betOption.ID,
+		OddsVersionID: oddsVersionID,
+		BetType:       betOption.Type,
+		BetBall:       betOption.Ball,
+		BetText:       betOption.Text,
+		BetBalls:      betOption.Balls,
 	}
 
-	// Fetch and store bet option details if BetOptionID is provided
-	if betInput.BetOptionID > 0 {
-		var betOption models.MiniBetOption
-		if err := initializers.DB.First(&betOption, betInput.BetOptionID).Error; err == nil {
-			powerballBet.BetType = betOption.Type
-			powerballBet.BetBall = betOption.Ball
-			powerballBet.BetText = betOption.Text
-			powerballBet.BetBalls = betOption.Balls
-		}
-	}
+	powerballBet.PickKey = minigame.PickKey(powerballBet)
 
 	// Start transaction
 	tx := initializers.DB.Begin()
 
+	// Book the potential payout against the round's exposure caps
+	if err := services.ReserveMiniBetExposure(tx, round, powerballBet.PickKey, amount, amount*odds); err != nil {
+		tx.Rollback()
+		var limitErr *services.MiniExposureLimitError
+		if errors.As(err, &limitErr) && limitErr.Suspend {
+			if _, err := services.SuspendMiniPick(initializers.DB, round, powerballBet.PickKey, "auto", nil); err != nil {
+				fmt.Printf("❌ Failed to suspend %s round %d pick %s: %v\n", round.GameType, round.Round, powerballBet.PickKey, err)
+			}
+		}
+		respondMiniBetError(c, err)
+		return
+	}
+
 	// Save bet to database
 	if err := tx.Create(&powerballBet).Error; err != nil {
 		tx.Rollback()
@@ -715,6 +661,63 @@ func PlaceMiniBet(c *gin.Context) {
 	})
 }
 
+// versionMiniOddsNow records odds edited in place as a version effective immediately,
+// attributed to the signed in user. Unchanged odds are not an error.
+func versionMiniOddsNow(c *gin.Context, tx *gorm.DB, gameType string, odds map[uint]string) error {
+	user, err := helpers.GetGinAuthUser(c)
+	if err != nil {
+		return err
+	}
+
+	_, err = services.CreateMiniOddsVersion(tx, gameType, time.Now(), "", &user.ID, odds)
+	if errors.Is(err, services.ErrMiniOddsNoChanges) {
+		return nil
+	}
+	return err
+}
+
+func respondMiniBetError(c *gin.Context, err error) {
+	var limitErr *services.MiniBetLimitError
+	var exposureErr *services.MiniExposureLimitError
+	switch {
+	case errors.As(err, &limitErr),
+		errors.As(err, &exposureErr),
+		errors.Is(err, services.ErrMiniPickSuspended),
+		errors.Is(err, services.ErrMiniBetOptionNotFound),
+		errors.Is(err, services.ErrMiniBetOptionDisabled),
+		errors.Is(err, services.ErrMiniBetOptionOdds):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+	case errors.Is(err, services.ErrMiniGameInactive):
+		format_errors.ForbbidenError(c, err)
+	default:
+		format_errors.InternalServerError(c, err)
+	}
+}
+
+// GetMiniGameRound returns the current round of a game type (?gameType=eos1min)
+func GetMiniGameRound(c *gin.Context) {
+	round, err := minigame.CurrentRound(c.Request.Context(), c.Query("gameType"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid game type",
+		})
+		return
+	}
+
+	now := time.Now()
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": gin.H{
+			"round":         round,
+			"status":        round.Status(now),
+			"serverTime":    now,
+			"secondsToDraw": int(round.DrawAt.Sub(now).Seconds()),
+		},
+	})
+}
+
 // GetMiniBetHistory gets betting history for authenticated user
 func GetMiniBetHistory(c *gin.Context) {
 	// Get authenticated user
@@ -776,7 +779,7 @@ func GetMiniBetHistory(c *gin.Context) {
 
 	// Get betting history with pagination
 	var betHistory []models.PowerballHistory
-	result := query.Order("created_at DESC").Limit(limit).Offset(offset).Find(&betHistory)
+	result := query.Preload("Draw").Order("created_at DESC").Limit(limit).Offset(offset).Find(&betHistory)
 
 	if result.Error != nil {
 		format_errors.InternalServerError(c, result.Error)
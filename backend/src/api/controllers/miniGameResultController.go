@@ -0,0 +1,122 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hotbrainy/go-betting/backend/db/initializers"
+	format_errors "github.com/hotbrainy/go-betting/backend/internal/format-errors"
+	"github.com/hotbrainy/go-betting/backend/internal/minigame"
+	"github.com/hotbrainy/go-betting/backend/internal/models"
+	"github.com/hotbrainy/go-betting/backend/internal/services"
+)
+
+// miniGameTypeQuery returns the gameType query parameter, responding with 400 when it is unknown
+func miniGameTypeQuery(c *gin.Context) (string, bool) {
+	gameType := c.Query("gameType")
+	if _, ok := minigame.Schedules[gameType]; !ok {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid game type",
+		})
+		return "", false
+	}
+	return gameType, true
+}
+
+func queryLimit(c *gin.Context, fallback, max int) int {
+	limit, err := strconv.Atoi(c.Query("limit"))
+	if err != nil || limit < 1 {
+		return fallback
+	}
+	if limit > max {
+		return max
+	}
+	return limit
+}
+
+// GetMiniGameResults returns the most recent draws of a game type (?gameType=eos1min&limit=20)
+func GetMiniGameResults(c *gin.Context) {
+	gameType, ok := miniGameTypeQuery(c)
+	if !ok {
+		return
+	}
+
+	var draws []models.MiniGameDraw
+	if err := initializers.DB.
+		Where("game_type = ?", gameType).
+		Order("drawn_at DESC").
+		Limit(queryLimit(c, 20, 100)).
+		Find(&draws).Error; err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    draws,
+	})
+}
+
+// GetMiniGameResultStats returns odd/even and under/over streaks and the size and section
+// distribution over the most recent draws (?gameType=eos1min&limit=100)
+func GetMiniGameResultStats(c *gin.Context) {
+	gameType, ok := miniGameTypeQuery(c)
+	if !ok {
+		return
+	}
+
+	var draws []models.MiniGameDraw
+	if err := initializers.DB.
+		Where("game_type = ?", gameType).
+		Order("drawn_at DESC").
+		Limit(queryLimit(c, 100, 1000)).
+		Find(&draws).Error; err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	// Streaks are read oldest first
+	for i, j := 0, len(draws)-1; i < j; i, j = i+1, j-1 {
+		draws[i], draws[j] = draws[j], draws[i]
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    services.BuildMiniGameDrawStats(draws),
+	})
+}
+
+// GetMiniGameDailyResults returns every draw of one game day (?gameType=eos1min&date=2006-01-02),
+// today by default
+func GetMiniGameDailyResults(c *gin.Context) {
+	gameType, ok := miniGameTypeQuery(c)
+	if !ok {
+		return
+	}
+
+	date := c.DefaultQuery("date", time.Now().In(minigame.Location).Format("2006-01-02"))
+	if _, err := time.Parse("2006-01-02", date); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid date, expected YYYY-MM-DD",
+		})
+		return
+	}
+
+	var draws []models.MiniGameDraw
+	if err := initializers.DB.
+		Where("game_type = ? AND draw_date = ?", gameType, date).
+		Order("round ASC").
+		Find(&draws).Error; err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    draws,
+		"date":    date,
+		"count":   len(draws),
+	})
+}
@@ -10,9 +10,9 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
 	"github.com/hotbrainy/go-betting/backend/db/initializers"
+	"github.com/hotbrainy/go-betting/backend/internal/casino"
 	format_errors "github.com/hotbrainy/go-betting/backend/internal/format-errors"
 	"github.com/hotbrainy/go-betting/backend/internal/helpers"
-	"github.com/hotbrainy/go-betting/backend/internal/honorlinkapi"
 	"github.com/hotbrainy/go-betting/backend/internal/models"
 	responses "github.com/hotbrainy/go-betting/backend/internal/response"
 	"github.com/hotbrainy/go-betting/backend/internal/validations"
@@ -485,18 +485,10 @@ func RegisterDirectMember(c *gin.Context) {
 		return
 	}
 
-	// checking the user status on the honorlink api, then if it is not exist, creating the honorlink user account.
-	// if the user is exist, skip the creating the honorlink user account.
-	userExists, err := honorlinkapi.CheckUserExists(userInput.Userid)
-	if err != nil {
+	// creating the casino user account on every provider, skipped where it exists already.
+	if err := casino.ProvisionUser(c.Request.Context(), userInput.Userid); err != nil {
 		// Log error but don't fail the signup process
-		fmt.Printf("Error checking Honorlink user: %v\n", err)
-	} else if !userExists {
-		// User doesn't exist, create it
-		if err := honorlinkapi.CreateUser(userInput.Userid); err != nil {
-			// Log error but don't fail the signup process
-			fmt.Printf("Error creating Honorlink user: %v\n", err)
-		}
+		fmt.Printf("Error provisioning casino user: %v\n", err)
 	}
 
 	c.JSON(http.StatusOK, responses.Status{
@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"fmt"
 	"net/http"
 	"strconv"
 
@@ -11,6 +12,7 @@ import (
 	"github.com/hotbrainy/go-betting/backend/internal/helpers"
 	"github.com/hotbrainy/go-betting/backend/internal/models"
 	"github.com/hotbrainy/go-betting/backend/internal/pagination"
+	"github.com/hotbrainy/go-betting/backend/internal/services"
 	"github.com/hotbrainy/go-betting/backend/internal/validations"
 	"gorm.io/gorm"
 )
@@ -36,6 +38,7 @@ func CreatePost(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"error": err.Error(),
 		})
+		return
 	}
 
 	if !validations.IsExistValue("categories", "id", userInput.CategoryId) {
@@ -55,6 +58,11 @@ func CreatePost(c *gin.Context) {
 		return
 	}
 
+	if authUser.CreatePost == "2" {
+		format_errors.ForbbidenError(c, fmt.Errorf("You are not allowed to create posts"))
+		return
+	}
+
 	post := models.Post{
 		Title:      userInput.Title,
 		Body:       userInput.Body,
@@ -62,16 +70,30 @@ func CreatePost(c *gin.Context) {
 		UserID:     authUser.ID,
 	}
 
-	result := initializers.DB.Create(&post)
+	tx := initializers.DB.Begin()
 
-	if err := result.Error; err != nil {
+	if err := tx.Create(&post).Error; err != nil {
+		tx.Rollback()
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	award, err := services.AwardPostPoints(tx, authUser, post.ID, nil)
+	if err != nil {
+		tx.Rollback()
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	if err := tx.Commit().Error; err != nil {
 		format_errors.InternalServerError(c, err)
 		return
 	}
 
 	// Return the post
 	c.JSON(http.StatusOK, gin.H{
-		"post": post,
+		"post":  post,
+		"award": award,
 	})
 }
 
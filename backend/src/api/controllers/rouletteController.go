@@ -0,0 +1,252 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/hotbrainy/go-betting/backend/db/initializers"
+	format_errors "github.com/hotbrainy/go-betting/backend/internal/format-errors"
+	"github.com/hotbrainy/go-betting/backend/internal/helpers"
+	"github.com/hotbrainy/go-betting/backend/internal/models"
+	"github.com/hotbrainy/go-betting/backend/internal/services"
+	"gorm.io/gorm"
+)
+
+// GetRoulette returns the wheel, the authenticated user's available spins and active seed
+func GetRoulette(c *gin.Context) {
+	user, err := helpers.GetGinAuthUser(c)
+	if err != nil {
+		format_errors.UnauthorizedError(c, err)
+		return
+	}
+
+	config := services.GetRouletteConfig(initializers.DB)
+
+	var segments []models.RouletteSegment
+	if err := initializers.DB.Where("is_active = ?", true).Order("sort_order ASC, id ASC").Find(&segments).Error; err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	tx := initializers.DB.Begin()
+
+	spins, err := services.AvailableRouletteSpins(tx, user.ID)
+	if err != nil {
+		tx.Rollback()
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	seed, err := services.ActiveRouletteSeed(tx, user.ID)
+	if err != nil {
+		tx.Rollback()
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": gin.H{
+			"isActive":       config.IsActive && user.UseRoulette != "2",
+			"segments":       segments,
+			"availableSpins": spins,
+			"seed":           seed,
+		},
+	})
+}
+
+// SpinRoulette uses one of the authenticated user's spins and pays the prize
+func SpinRoulette(c *gin.Context) {
+	user, err := helpers.GetGinAuthUser(c)
+	if err != nil {
+		format_errors.UnauthorizedError(c, err)
+		return
+	}
+
+	if user.UseRoulette == "2" {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": "Roulette is not available for this account",
+		})
+		return
+	}
+
+	tx := initializers.DB.Begin()
+
+	spin, err := services.SpinRoulette(tx, user.ID)
+	if err != nil {
+		tx.Rollback()
+		respondRouletteError(c, err)
+		return
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	spins, _ := services.AvailableRouletteSpins(initializers.DB, user.ID)
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": gin.H{
+			"spin":           spin,
+			"availableSpins": spins,
+		},
+	})
+}
+
+// GetMyRouletteSpins returns the authenticated user's spin history
+func GetMyRouletteSpins(c *gin.Context) {
+	user, err := helpers.GetGinAuthUser(c)
+	if err != nil {
+		format_errors.UnauthorizedError(c, err)
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if page < 1 {
+		page = 1
+	}
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("pageSize", "20"))
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	query := initializers.DB.Model(&models.RouletteSpin{}).Where("user_id = ?", user.ID)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	var spins []models.RouletteSpin
+	if err := query.Order("created_at DESC").Offset((page - 1) * pageSize).Limit(pageSize).Find(&spins).Error; err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":  true,
+		"data":     spins,
+		"total":    total,
+		"page":     page,
+		"pageSize": pageSize,
+	})
+}
+
+// RotateRouletteSeed reveals the authenticated user's current server seed and starts a new one
+func RotateRouletteSeed(c *gin.Context) {
+	user, err := helpers.GetGinAuthUser(c)
+	if err != nil {
+		format_errors.UnauthorizedError(c, err)
+		return
+	}
+
+	var userInput struct {
+		ClientSeed string `json:"clientSeed"`
+	}
+	// Body is optional, an empty client seed is generated
+	_ = c.ShouldBindJSON(&userInput)
+
+	if len(userInput.ClientSeed) > 64 {
+		format_errors.BadRequestError(c, fmt.Errorf("clientSeed must be at most 64 characters"))
+		return
+	}
+
+	tx := initializers.DB.Begin()
+
+	revealed, next, err := services.RotateRouletteSeed(tx, user.ID, userInput.ClientSeed)
+	if err != nil {
+		tx.Rollback()
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		format_errors.InternalServerError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Seed rotated successfully",
+		"data": gin.H{
+			"revealed": gin.H{
+				"id":             revealed.ID,
+				"serverSeed":     revealed.ServerSeed,
+				"serverSeedHash": revealed.ServerSeedHash,
+				"clientSeed":     revealed.ClientSeed,
+				"nonce":          revealed.Nonce,
+			},
+			"seed": next,
+		},
+	})
+}
+
+// VerifyMyRouletteSpin recomputes one of the authenticated user's spins from its revealed seed
+func VerifyMyRouletteSpin(c *gin.Context) {
+	user, err := helpers.GetGinAuthUser(c)
+	if err != nil {
+		format_errors.UnauthorizedError(c, err)
+		return
+	}
+
+	var spin models.RouletteSpin
+	if err := initializers.DB.Where("id = ? AND user_id = ?", c.Param("id"), user.ID).First(&spin).Error; err != nil {
+		format_errors.NotFound(c, err)
+		return
+	}
+
+	var seed models.RouletteSeed
+	if err := initializers.DB.First(&seed, spin.SeedID).Error; err != nil {
+		format_errors.NotFound(c, err)
+		return
+	}
+
+	roll, segmentID, err := services.VerifyRouletteSpin(&spin, &seed)
+	if err != nil {
+		respondRouletteError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": gin.H{
+			"serverSeed":     seed.ServerSeed,
+			"serverSeedHash": seed.ServerSeedHash,
+			"clientSeed":     seed.ClientSeed,
+			"nonce":          spin.Nonce,
+			"segments":       spin.Segments,
+			"roll":           roll,
+			"segmentId":      segmentID,
+			"verified":       roll == spin.Roll && segmentID == spin.SegmentID,
+		},
+	})
+}
+
+// respondRouletteError maps roulette service errors to responses
+func respondRouletteError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		format_errors.NotFound(c, err)
+	case errors.Is(err, services.ErrRouletteInactive):
+		format_errors.ForbbidenError(c, err)
+	case errors.Is(err, services.ErrRouletteBusy):
+		format_errors.ConflictError(c, err)
+	case errors.Is(err, services.ErrRouletteNoSpins),
+		errors.Is(err, services.ErrRouletteNoSegments),
+		errors.Is(err, services.ErrRouletteNotRevealed):
+		format_errors.BadRequestError(c, err)
+	default:
+		respondCouponError(c, err)
+	}
+}
@@ -1,158 +1,98 @@
 package controllers
 
 import (
-	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
 	"net/http"
-	"net/url"
 
 	"github.com/gin-gonic/gin"
+	"github.com/hotbrainy/go-betting/backend/db/initializers"
+	"github.com/hotbrainy/go-betting/backend/internal/casino"
+	"github.com/hotbrainy/go-betting/backend/internal/helpers"
+	"github.com/hotbrainy/go-betting/backend/internal/models"
+	"github.com/hotbrainy/go-betting/backend/internal/services"
 )
 
-// API configuration
-// const (
-// 	baseURL     = "https://api.honorlink.org/api"
-// 	bearerToken = "srDiqct6lH61a0zHNKPUu0IwE0mg7Ht38sALu3oWb5bf8e9d"
-// )
-
+// GetGameItems lists the games of a vendor from the local catalog only. A new vendor is
+// filled by the catalog sync or by admins, never by a lobby request.
 func GetGameItems(c *gin.Context) {
 	vendor := c.Query("vendor")
 	gameType := c.Query("type")
 
-	endpoint := fmt.Sprintf("https://api.honorlink.org/api/game-list?vendor=%s&type=%s", vendor, gameType)
-	bearerToken := "srDiqct6lH61a0zHNKPUu0IwE0mg7Ht38sALu3oWb5bf8e9d"
-	client := &http.Client{}
-	req, err := http.NewRequest("GET", endpoint, nil)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Failed to create test request",
-			"details": err.Error(),
-		})
-		return
+	if vendor != "" {
+		if _, err := casino.ForVendor(initializers.DB, vendor); errors.Is(err, casino.ErrVendorDisabled) {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error":   "Casino vendor is disabled",
+				"details": err.Error(),
+			})
+			return
+		}
 	}
-	req.Header.Set("Authorization", "Bearer "+bearerToken)
 
-	resp, err := client.Do(req)
-	if err != nil {
+	var games []models.CasinoGame
+	if err := services.CasinoGameQuery(initializers.DB, services.CasinoGameFilter{
+		Vendor:  vendor,
+		Type:    gameType,
+		Visible: true,
+	}).Order(services.CasinoGameLobbyOrder).Find(&games).Error; err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error":   "Failed to fetch game items",
 			"details": err.Error(),
 		})
 		return
 	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Failed to read response body",
-			"details": err.Error(),
-		})
-		return
-	}
 
-	var gameItems []map[string]interface{}
-	err = json.Unmarshal(body, &gameItems)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Failed to parse game items",
-			"details": err.Error(),
+	// Keep the fields of the provider's game list, id is the provider's game ID to launch
+	gameItems := make([]gin.H, 0, len(games))
+	for _, game := range games {
+		gameItems = append(gameItems, gin.H{
+			"id":        game.GameID,
+			"vendor":    game.Vendor,
+			"type":      game.Type,
+			"title":     game.Title,
+			"thumbnail": game.Thumbnail,
+			"catalogId": game.ID,
+			"featured":  game.Featured,
+			"tags":      game.Tags,
 		})
-		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"data": gameItems})
 }
 
-// // getGameLaunchLink retrieves the game launch link with token refresh handling
+// GetSlotLaunchLink returns the launch link of a slot game
 func GetSlotLaunchLink(c *gin.Context) {
-	username := c.Query("username")
-	nickname := c.Query("nickname")
-	gameID := c.Query("game_id")
 	vendor := c.Query("vendor")
 
-	reqURL, err := url.Parse("https://api.honorlink.org/api/game-launch-link")
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Failed to parse game launch link",
-			"details": err.Error(),
-		})
-		return
-	}
-
-	q := reqURL.Query()
-	q.Set("username", username)
-	q.Set("nickname", nickname)
-	q.Set("game_id", fmt.Sprintf("%v", gameID))
-	q.Set("vendor", vendor)
-
-	// Use user token if provided, otherwise use bearer token
-	// if userToken != "" {
-	// 	q.Set("token", userToken)
-	// }
-
-	reqURL.RawQuery = q.Encode()
-
-	req, err := http.NewRequest("GET", reqURL.String(), nil)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Failed to create test request",
-			"details": err.Error(),
-		})
+	if !casinoVendorAllowed(c, vendor, services.CasinoCategorySlot, c.Query("username")) {
 		return
 	}
 
-	// Set appropriate headers
-	req.Header.Set("Authorization", "Bearer "+bearerToken)
-	req.Header.Set("Content-Type", "application/json")
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Failed to fetch game items",
-			"details": err.Error(),
-		})
+	provider, ok := casinoProvider(c, vendor)
+	if !ok {
 		return
 	}
-	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
+	link, err := provider.LaunchLink(c.Request.Context(), casino.LaunchRequest{
+		Username: c.Query("username"),
+		Nickname: c.Query("nickname"),
+		Vendor:   vendor,
+		GameID:   c.Query("game_id"),
+	})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Failed to read response body",
-			"details": err.Error(),
-		})
-		return
-	}
-
-	if resp.StatusCode != 200 {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error":   "Failed to get game link",
-			"details": fmt.Sprintf("status: %d, body: %s", resp.StatusCode, string(body)),
-		})
-		return
-	}
-
-	// Parse response to get the link
-	var response map[string]interface{}
-	if err := json.Unmarshal(body, &response); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error":   "Failed to parse game link",
 			"details": err.Error(),
 		})
 		return
 	}
 
-	// Try different possible response field names
-	for _, field := range []string{"link", "url", "game_link"} {
-		if link, ok := response[field].(string); ok {
-			c.JSON(http.StatusOK, gin.H{"data": link})
-			return
+	// Remember the launch for the recently played list
+	if user, err := helpers.GetGinAuthUser(c); err == nil {
+		if err := services.RecordCasinoGamePlay(initializers.DB, user.ID, provider.Name(), vendor, c.Query("game_id")); err != nil {
+			fmt.Printf("Error recording casino game play: %v\n", err)
 		}
 	}
 
-	// If no link found in response, return the raw response
-	c.JSON(http.StatusOK, gin.H{"data": string(body)})
+	c.JSON(http.StatusOK, gin.H{"data": link})
 }
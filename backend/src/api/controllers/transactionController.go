@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -10,7 +11,9 @@ import (
 	adminControllers "github.com/hotbrainy/go-betting/backend/api/controllers/admin"
 	"github.com/hotbrainy/go-betting/backend/db/initializers"
 	format_errors "github.com/hotbrainy/go-betting/backend/internal/format-errors"
+	"github.com/hotbrainy/go-betting/backend/internal/helpers"
 	"github.com/hotbrainy/go-betting/backend/internal/models"
+	"github.com/hotbrainy/go-betting/backend/internal/services"
 	"github.com/hotbrainy/go-betting/backend/internal/validations"
 )
 
@@ -65,6 +68,11 @@ func CreateTransaction(c *gin.Context) {
 		return
 	}
 
+	if transactionInput.Type == "rollingExchange" {
+		createRollingConversion(c, transactionInput.Amount, transactionInput.Explation)
+		return
+	}
+
 	// Get user's profile to check current balance
 	var profile models.Profile
 	if err := initializers.DB.Where("user_id = ?", transactionInput.UserId).First(&profile).Error; err != nil {
@@ -98,19 +106,6 @@ func CreateTransaction(c *gin.Context) {
 		pointAfter = pointBefore - transactionInput.Amount
 		balanceAfter = balanceBefore + transactionInput.Amount
 		balanceBefore = profile.Balance
-	} else if transactionInput.Type == "rollingExchange" {
-		// Check if user has sufficient rolling
-		if profile.Roll < transactionInput.Amount {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error": "Insufficient rolling for conversion",
-			})
-			return
-		}
-		// For rolling exchange, PointBefore/PointAfter represent rolling before/after
-		pointBefore = profile.Roll
-		pointAfter = profile.Roll - transactionInput.Amount
-		balanceAfter = balanceBefore + transactionInput.Amount
-		balanceBefore = profile.Balance
 	}
 
 	// Create transaction record
@@ -126,11 +121,13 @@ func CreateTransaction(c *gin.Context) {
 		Status:        "pending",
 	}
 
+	var user models.User
+	userErr := initializers.DB.First(&user, transactionInput.UserId).Error
+
 	initializers.DB.Create(&transaction)
 
 	// Create alert for admin
-	var user models.User
-	if err := initializers.DB.First(&user, transactionInput.UserId).Error; err == nil {
+	if userErr == nil {
 		var alertType, title, message, redirectURL string
 		switch transactionInput.Type {
 		case "deposit":
@@ -145,10 +142,6 @@ func CreateTransaction(c *gin.Context) {
 			alertType = "point"
 			title = "New Point Conversion Request"
 			message = fmt.Sprintf("User %s (ID: %d) requested to convert %.2f points to balance", user.Userid, user.ID, transactionInput.Amount)
-		case "rollingExchange":
-			alertType = "rollingExchange"
-			title = "New Rolling Conversion Request"
-			message = fmt.Sprintf("User %s (ID: %d) requested to convert %.2f rolling to balance", user.Userid, user.ID, transactionInput.Amount)
 		}
 		
 		// Set redirect URL based on user role
@@ -171,3 +164,54 @@ func CreateTransaction(c *gin.Context) {
 		},
 	})
 }
+
+// createRollingConversion converts the authenticated user's rolling to balance: users flagged for
+// automatic approval get it converted right away, the others' requests wait for an admin
+func createRollingConversion(c *gin.Context, amount float64, explanation string) {
+	user, err := helpers.GetGinAuthUser(c)
+	if err != nil {
+		format_errors.UnauthorizedError(c, err)
+		return
+	}
+
+	autoApprove := user.RollingConversionAutomaticApproval == "1"
+	transaction, err := services.RequestRollingConversion(initializers.DB, user.ID, amount, explanation, autoApprove)
+	if err != nil {
+		var limitErr *services.RollingConversionLimitError
+		if errors.Is(err, services.ErrRollingInsufficient) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Insufficient rolling for conversion",
+			})
+		} else if errors.As(err, &limitErr) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": limitErr.Error(),
+			})
+		} else {
+			format_errors.InternalServerError(c, err)
+		}
+		return
+	}
+
+	message := "Rolling converted successfully"
+	if !autoApprove {
+		message = "Transaction created successfully"
+
+		// Set redirect URL based on user role
+		redirectURL := "/admin/financals/memberdwhistory"
+		if user.Role == "A" || user.Role == "P" {
+			redirectURL = "/admin/financals/general"
+		}
+		adminControllers.CreateAlert("rollingExchange", "New Rolling Conversion Request",
+			fmt.Sprintf("User %s (ID: %d) requested to convert %.2f rolling to balance", user.Userid, user.ID, amount),
+			transaction.ID, redirectURL)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": message,
+		"data": gin.H{
+			"transaction": transaction,
+			"newBalance":  transaction.BalanceAfter,
+			"status":      true,
+		},
+	})
+}
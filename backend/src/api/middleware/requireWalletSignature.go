@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	format_errors "github.com/hotbrainy/go-betting/backend/internal/format-errors"
+)
+
+// walletSignatureMaxSkew bounds how old a signed wallet callback may be, against replays
+const walletSignatureMaxSkew = 5 * time.Minute
+
+// RequireWalletSignature verifies casino wallet callbacks. The aggregator signs each request
+// with the shared CASINO_WALLET_SECRET: X-Signature is the hex HMAC-SHA256 of
+// "<X-Timestamp>.<raw body>", X-Timestamp the Unix time in seconds.
+func RequireWalletSignature(c *gin.Context) {
+	secret := os.Getenv("CASINO_WALLET_SECRET")
+	if secret == "" {
+		format_errors.UnauthorizedError(c, fmt.Errorf("wallet callbacks are not configured"))
+		return
+	}
+
+	timestamp := c.GetHeader("X-Timestamp")
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		format_errors.UnauthorizedError(c, fmt.Errorf("invalid timestamp"))
+		return
+	}
+	if skew := time.Since(time.Unix(unix, 0)); skew > walletSignatureMaxSkew || skew < -walletSignatureMaxSkew {
+		format_errors.UnauthorizedError(c, fmt.Errorf("expired timestamp"))
+		return
+	}
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		format_errors.BadRequestError(c, err)
+		return
+	}
+	// Put the body back for the handler to bind
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	expected := mac.Sum(nil)
+
+	signature, err := hex.DecodeString(c.GetHeader("X-Signature"))
+	if err != nil || !hmac.Equal(signature, expected) {
+		format_errors.UnauthorizedError(c, fmt.Errorf("invalid signature"))
+		return
+	}
+
+	c.Next()
+}
@@ -28,6 +28,7 @@ func GetAdminRoute(r *gin.RouterGroup) {
 		userRouter.GET("/all-trash", controllers.GetTrashedUsers)
 		userRouter.DELETE("/delete-permanent/:id", controllers.PermanentlyDeleteUser)
 		userRouter.POST("/reset-coupons", controllers.ResetAllCoupons)
+		userRouter.POST("/rebuild-betting-stats", controllers.RebuildUserBettingStats)
 	}
 
 	// Category routes
@@ -170,10 +171,66 @@ func GetAdminRoute(r *gin.RouterGroup) {
 		miniRouter.PATCH("/options/:id/toggle", controllers.AdminToggleMiniBetOption)
 		miniRouter.PUT("/options/bulk-update", controllers.AdminBulkUpdateMiniBetOptions)
 		miniRouter.POST("/options/initialize-defaults", controllers.AdminInitializeDefaultMiniBetOptions)
+		miniRouter.GET("/options/:id/odds-history", controllers.AdminGetMiniBetOptionOddsHistory)
+
+		// Odds versions
+		miniRouter.GET("/odds-versions", controllers.AdminGetMiniOddsVersions)
+		miniRouter.POST("/odds-versions", controllers.AdminCreateMiniOddsVersion)
+		miniRouter.DELETE("/odds-versions/:id", controllers.AdminCancelMiniOddsVersion)
 
 		// Mini game configs
 		miniRouter.GET("/configs", controllers.AdminGetMiniGameConfigs)
 		miniRouter.PUT("/configs", controllers.AdminUpdateMiniGameConfig)
+
+		// Per-round exposure caps and live book
+		miniRouter.GET("/exposure/limits", controllers.AdminGetMiniExposureLimits)
+		miniRouter.PUT("/exposure/limits", controllers.AdminUpdateMiniExposureLimit)
+		miniRouter.GET("/exposure/book", controllers.AdminGetMiniExposureBook)
+		miniRouter.POST("/exposure/suspensions", controllers.AdminSuspendMiniPick)
+		miniRouter.DELETE("/exposure/suspensions/:id", controllers.AdminLiftMiniPickSuspension)
+
+		// Round cancellation and manual results
+		miniRouter.GET("/settlement-config", controllers.AdminGetMiniSettlementConfig)
+		miniRouter.PUT("/settlement-config", controllers.AdminUpdateMiniSettlementConfig)
+		miniRouter.GET("/rounds/pending", controllers.AdminGetPendingMiniRounds)
+		miniRouter.POST("/rounds/cancel", controllers.AdminCancelMiniRound)
+		miniRouter.POST("/rounds/result", controllers.AdminSettleMiniRound)
+		miniRouter.GET("/rounds/actions", controllers.AdminGetMiniRoundActions)
+	}
+
+	// Casino admin routes
+	casinoRouter := r.Group("/casino")
+	{
+		// HonorLink transaction sync
+		casinoRouter.GET("/honorlink/sync", controllers.AdminGetHonorLinkSync)
+		casinoRouter.POST("/honorlink/backfills", controllers.AdminCreateHonorLinkBackfill)
+
+		// Game catalog curation
+		casinoRouter.GET("/games", controllers.AdminGetCasinoGames)
+		casinoRouter.PUT("/games/:id", controllers.AdminUpdateCasinoGame)
+		casinoRouter.POST("/games/reorder", controllers.AdminReorderCasinoGames)
+		casinoRouter.POST("/games/sync", controllers.AdminSyncCasinoGames)
+
+		// Vendor availability, maintenance and restrictions
+		casinoRouter.GET("/vendors", controllers.AdminGetCasinoVendors)
+		casinoRouter.POST("/vendors", controllers.AdminCreateCasinoVendor)
+		casinoRouter.PUT("/vendors/:id", controllers.AdminUpdateCasinoVendor)
+		casinoRouter.DELETE("/vendors/:id", controllers.AdminDeleteCasinoVendor)
+
+		// Betting limits
+		casinoRouter.GET("/limits/breaches", controllers.AdminGetCasinoLimitBreaches)
+		casinoRouter.GET("/limits/users/:id", controllers.AdminGetUserCasinoLimits)
+		casinoRouter.PUT("/limits/users/:id", controllers.AdminUpdateUserCasinoLimits)
+
+		// Bet search and GGR reports
+		casinoRouter.GET("/bets", controllers.AdminSearchCasinoBets)
+		casinoRouter.GET("/reports/ggr", controllers.AdminGetCasinoGGR)
+		casinoRouter.GET("/reports/ggr/users", controllers.AdminGetCasinoUserGGR)
+		casinoRouter.GET("/reports/top-players", controllers.AdminGetCasinoTopPlayers)
+
+		// Balance transfers between profiles and casino accounts
+		casinoRouter.GET("/transfers", controllers.AdminGetCasinoTransfers)
+		casinoRouter.POST("/transfers/:id/resolve", controllers.AdminResolveCasinoTransfer)
 	}
 
 	// Alert routes
@@ -187,6 +244,42 @@ func GetAdminRoute(r *gin.RouterGroup) {
 		alertRouter.DELETE("/delete", controllers.DeleteAlert)
 	}
 
+	// Attendance routes
+	attendanceRouter := r.Group("/attendance")
+	{
+		attendanceRouter.GET("/config", controllers.GetAttendanceConfig)
+		attendanceRouter.PUT("/config", controllers.UpdateAttendanceConfig)
+		attendanceRouter.GET("/rewards", controllers.GetAttendanceRewards)
+		attendanceRouter.POST("/rewards", controllers.UpsertAttendanceReward)
+		attendanceRouter.DELETE("/rewards/:id", controllers.DeleteAttendanceReward)
+		attendanceRouter.GET("/report", controllers.GetAttendanceReport)
+	}
+
+	couponRouter := r.Group("/coupons")
+	{
+		couponRouter.GET("/campaigns", controllers.GetCouponCampaigns)
+		couponRouter.POST("/campaigns", controllers.CreateCouponCampaign)
+		couponRouter.PUT("/campaigns/:id", controllers.UpdateCouponCampaign)
+		couponRouter.DELETE("/campaigns/:id", controllers.DeleteCouponCampaign)
+		couponRouter.POST("/campaigns/:id/issue", controllers.IssueCouponCampaign)
+		couponRouter.GET("/issued", controllers.GetUserCoupons)
+		couponRouter.POST("/issued/:id/revoke", controllers.RevokeUserCoupon)
+		couponRouter.GET("/redemptions", controllers.GetCouponRedemptions)
+	}
+
+	rouletteRouter := r.Group("/roulette")
+	{
+		rouletteRouter.GET("/config", controllers.GetRouletteConfig)
+		rouletteRouter.PUT("/config", controllers.UpdateRouletteConfig)
+		rouletteRouter.GET("/segments", controllers.GetRouletteSegments)
+		rouletteRouter.POST("/segments", controllers.CreateRouletteSegment)
+		rouletteRouter.PUT("/segments/:id", controllers.UpdateRouletteSegment)
+		rouletteRouter.DELETE("/segments/:id", controllers.DeleteRouletteSegment)
+		rouletteRouter.POST("/grant", controllers.GrantRouletteSpins)
+		rouletteRouter.GET("/spins", controllers.GetRouletteSpins)
+		rouletteRouter.GET("/spins/:id/verify", controllers.VerifyRouletteSpin)
+	}
+
 	// Sample QNA routes
 	sampleQnaRouter := r.Group("/sample-qnas")
 	{
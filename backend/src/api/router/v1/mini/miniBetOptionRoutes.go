@@ -12,6 +12,12 @@ func GetMiniBetOptionRoute(r *gin.RouterGroup) {
 	r.GET("/options/:id", controllers.GetMiniBetOption)
 	r.GET("/configs", controllers.GetMiniGameConfigs)
 	r.GET("/game-distribution", controllers.GetGameDistribution)
+	r.GET("/round", controllers.GetMiniGameRound)
+	r.GET("/results", controllers.GetMiniGameResults)
+	r.GET("/results/stats", controllers.GetMiniGameResultStats)
+	r.GET("/results/daily", controllers.GetMiniGameDailyResults)
+	r.GET("/house/commitment", controllers.GetHouseCommitment)
+	r.GET("/house/verify", controllers.VerifyHouseRound)
 
 	// Protected routes (require auth)
 	r.Use(middleware.RequireAuth)
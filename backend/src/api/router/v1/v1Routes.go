@@ -23,11 +23,21 @@ func GetV1Route(r *gin.RouterGroup) {
 			"message": "BACKEND API RUNNING",
 		})
 	})
+	r.GET("/healthz/honorlink", controllers.GetHonorLinkSyncHealth)
 	r.GET("/lang/:locale", controllers.GetLang)
 
 	// Public contact info endpoint
 	r.GET("/contact-info", controllers.GetPublicContactInfo)
 
+	// Seamless wallet callbacks of the casino aggregator, signed instead of user authenticated
+	walletRouter := r.Group("/casino/wallet", middleware.RequireWalletSignature)
+	{
+		walletRouter.POST("/balance", controllers.CasinoWalletBalance)
+		walletRouter.POST("/debit", controllers.CasinoWalletDebit)
+		walletRouter.POST("/credit", controllers.CasinoWalletCredit)
+		walletRouter.POST("/rollback", controllers.CasinoWalletRollback)
+	}
+
 	r.Use(middleware.LogAuth)
 
 	r.GET("/ws/info", controllers.Info)
@@ -111,6 +121,29 @@ func GetV1Route(r *gin.RouterGroup) {
 		qnaRouter.POST("/delete", controllers.DeleteQna)
 	}
 
+	attendanceRouter := r.Group("/attendance")
+	{
+		attendanceRouter.POST("/check-in", controllers.CheckInAttendance)
+		attendanceRouter.GET("/calendar", controllers.GetAttendanceCalendar)
+	}
+
+	couponRouter := r.Group("/coupons")
+	{
+		couponRouter.GET("", controllers.GetMyCoupons)
+		couponRouter.POST("/claim", controllers.ClaimCoupon)
+		couponRouter.POST("/:id/redeem", controllers.RedeemCoupon)
+		couponRouter.GET("/redemptions", controllers.GetMyCouponRedemptions)
+	}
+
+	rouletteRouter := r.Group("/roulette")
+	{
+		rouletteRouter.GET("", controllers.GetRoulette)
+		rouletteRouter.POST("/spin", controllers.SpinRoulette)
+		rouletteRouter.GET("/spins", controllers.GetMyRouletteSpins)
+		rouletteRouter.GET("/spins/:id/verify", controllers.VerifyMyRouletteSpin)
+		rouletteRouter.POST("/seed/rotate", controllers.RotateRouletteSeed)
+	}
+
 	gameApiRouter := r.Group("/game-api")
 	{
 		gameApiRouter.POST("/get-game-api", controllers.GetGameAPI)
@@ -130,6 +163,15 @@ func GetV1Route(r *gin.RouterGroup) {
 		casinoRouter.GET("/get-balance", controllers.GetBalance)
 		casinoRouter.GET("/add-balance", controllers.AddBalance)
 		casinoRouter.GET("/withdraw", controllers.Withdraw)
+
+		// Game catalog
+		casinoRouter.GET("/games", controllers.GetCasinoGames)
+		casinoRouter.GET("/games/categories", controllers.GetCasinoGameCategories)
+		casinoRouter.GET("/games/favorites", controllers.GetMyCasinoFavorites)
+		casinoRouter.GET("/games/recent", controllers.GetMyRecentCasinoGames)
+		casinoRouter.POST("/games/:id/favorite", controllers.AddCasinoFavorite)
+		casinoRouter.DELETE("/games/:id/favorite", controllers.RemoveCasinoFavorite)
+		casinoRouter.GET("/vendors", controllers.GetCasinoVendors)
 	}
 
 	slotRouter := r.Group("/slot")
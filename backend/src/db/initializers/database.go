@@ -100,6 +100,10 @@ func ConnectDB() {
 
 	fmt.Println("✅ Successfully connected to the database!")
 
+	if err := prepareMigration(); err != nil {
+		log.Fatalf("⛔ Migration preparation failed: %v", err)
+	}
+
 	err = DB.AutoMigrate(
 		models.User{},
 		models.Profile{},
@@ -107,6 +111,7 @@ func ConnectDB() {
 		models.Domain{},
 		models.Category{},
 		models.Post{},
+		models.PostPointAward{},
 		models.Comment{},
 		models.Sport{},
 		models.Rate{},
@@ -120,6 +125,16 @@ func ConnectDB() {
 		models.Transaction{},
 		models.Announcement{},
 		models.Attendance{},
+		models.AttendanceConfig{},
+		models.AttendanceReward{},
+		models.CouponCampaign{},
+		models.UserCoupon{},
+		models.CouponRedemption{},
+		models.RouletteConfig{},
+		models.RouletteSegment{},
+		models.RouletteEntitlement{},
+		models.RouletteSeed{},
+		models.RouletteSpin{},
 		models.Inbox{},
 		models.Notification{},
 		models.Alert{},
@@ -139,7 +154,27 @@ func ConnectDB() {
 		models.ChargeBonusTableLevel{},
 		models.MiniBetOption{},
 		models.MiniGameConfig{},
+		models.MiniOddsVersion{},
+		models.MiniOddsChange{},
+		models.MiniGameDraw{},
+		models.MiniGameSeed{},
+		models.MiniGameExposureLimit{},
+		models.MiniGameExposure{},
+		models.MiniGameSuspension{},
+		models.MiniGameSettlementConfig{},
+		models.MiniGameRoundAction{},
 		models.PowerballHistory{},
+		models.CasinoWalletTransaction{},
+		models.HonorLinkSyncState{},
+		models.HonorLinkBackfill{},
+		models.HonorLinkUnmatchedTransaction{},
+		models.CasinoGame{},
+		models.CasinoGameFavorite{},
+		models.CasinoGamePlay{},
+		models.CasinoRound{},
+		models.CasinoTransfer{},
+		models.CasinoVendor{},
+		models.CasinoLimitBreach{},
 		models.SampleQna{},
 	)
 
@@ -147,6 +182,9 @@ func ConnectDB() {
 		log.Fatal("⛔ Migration failed")
 	} else {
 		fmt.Println("🟢 Successfully migrated!")
+		if err := migrateData(); err != nil {
+			log.Fatalf("⛔ Data migration failed: %v", err)
+		}
 	}
 
 }
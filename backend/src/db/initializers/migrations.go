@@ -0,0 +1,143 @@
+package initializers
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hotbrainy/go-betting/backend/internal/minigame"
+	"github.com/hotbrainy/go-betting/backend/internal/models"
+)
+
+// prepareMigration fixes up existing data that the schema of AutoMigrate would reject
+func prepareMigration() error {
+	migrator := DB.Migrator()
+
+	// Casino bets became unique on their provider transaction ID; keep the first recording of
+	// a transaction synced twice before that
+	if migrator.HasTable(&models.CasinoBet{}) && !migrator.HasIndex(&models.CasinoBet{}, "idx_casino_bet_trans_id") {
+		result := DB.Exec(`DELETE FROM casino_bets a USING casino_bets b
+			WHERE a.trans_id = b.trans_id AND a.trans_id <> '' AND a.id > b.id`)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected > 0 {
+			fmt.Printf("🧹 Removed %d duplicate casino bets\n", result.RowsAffected)
+		}
+	}
+
+	return nil
+}
+
+// migrateData moves existing data into the schema AutoMigrate created
+func migrateData() error {
+	// Casino bets recorded before the play time was stored were played at their betting time
+	result := DB.Exec(`UPDATE casino_bets
+		SET played_at = CASE WHEN betting_time > 0 THEN to_timestamp(betting_time) ELSE created_at END
+		WHERE played_at IS NULL`)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected > 0 {
+		fmt.Printf("🕒 Set the play time of %d casino bets\n", result.RowsAffected)
+	}
+
+	return migrateLegacyMiniGameDraws()
+}
+
+// legacyMiniGameDraw is the draw a settled bet carried in its own columns before draws were
+// stored once in MiniGameDraw
+type legacyMiniGameDraw struct {
+	ID             uint
+	GameType       string
+	CreatedAt      time.Time
+	Times          uint64
+	FixedDateRound string
+
+	Ball1, Ball2, Ball3, Ball4, Ball5, PowerBall int
+
+	PowBallOe      string
+	PowBallUnover  string
+	DefBallSum     int
+	DefBallOe      string
+	DefBallUnover  string
+	DefBallSize    string
+	DefBallSection string
+}
+
+// migrateLegacyMiniGameDraws builds the draws of bets settled before MiniGameDraw existed from
+// the draw columns left on powerball_histories, and links the bets to them. The round is the
+// one the bet was placed in, the round settlement graded it against.
+func migrateLegacyMiniGameDraws() error {
+	if !DB.Migrator().HasColumn(&models.PowerballHistory{}, "drawing_date") {
+		return nil
+	}
+
+	draws := map[string]uint{}
+	linked := 0
+	var lastID uint
+	for {
+		var bets []legacyMiniGameDraw
+		if err := DB.Table("powerball_histories").
+			Select("id, game_type, created_at, times, fixed_date_round, ball1, ball2, ball3, ball4, ball5, power_ball, "+
+				"pow_ball_oe, pow_ball_unover, def_ball_sum, def_ball_oe, def_ball_unover, def_ball_size, def_ball_section").
+			Where("id > ? AND draw_id IS NULL AND status = ? AND COALESCE(drawing_date, '') <> ''", lastID, "done").
+			Order("id ASC").Limit(1000).
+			Scan(&bets).Error; err != nil {
+			return err
+		}
+		if len(bets) == 0 {
+			break
+		}
+
+		for _, bet := range bets {
+			lastID = bet.ID
+
+			round, err := minigame.RoundAt(bet.GameType, bet.CreatedAt)
+			if err != nil {
+				continue
+			}
+			key := fmt.Sprintf("%s:%s:%d", round.GameType, round.Date, round.Round)
+			drawID, ok := draws[key]
+			if !ok {
+				draw := models.MiniGameDraw{
+					GameType:         round.GameType,
+					DrawDate:         round.Date,
+					Round:            round.Round,
+					Times:            bet.Times,
+					FixedDateRound:   bet.FixedDateRound,
+					DrawnAt:          round.DrawAt,
+					Ball1:            bet.Ball1,
+					Ball2:            bet.Ball2,
+					Ball3:            bet.Ball3,
+					Ball4:            bet.Ball4,
+					Ball5:            bet.Ball5,
+					PowerBall:        bet.PowerBall,
+					PowBallOddEven:   bet.PowBallOe,
+					PowBallUnderOver: bet.PowBallUnover,
+					DefBallSum:       bet.DefBallSum,
+					DefBallOddEven:   bet.DefBallOe,
+					DefBallUnderOver: bet.DefBallUnover,
+					DefBallSize:      bet.DefBallSize,
+					DefBallSection:   bet.DefBallSection,
+				}
+				if err := DB.Where("game_type = ? AND draw_date = ? AND round = ?", draw.GameType, draw.DrawDate, draw.Round).
+					FirstOrCreate(&draw).Error; err != nil {
+					return err
+				}
+				drawID = draw.ID
+				draws[key] = drawID
+			}
+
+			if err := DB.Table("powerball_histories").Where("id = ?", bet.ID).
+				Update("draw_id", drawID).Error; err != nil {
+				return err
+			}
+			linked++
+		}
+	}
+
+	if linked > 0 {
+		fmt.Printf("🎱 Linked %d mini game bets to %d draws built from their legacy columns\n", linked, len(draws))
+	}
+	return nil
+}
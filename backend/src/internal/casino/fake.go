@@ -0,0 +1,178 @@
+package casino
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+var (
+	ErrFakeUnknownUser = errors.New("fake casino user not found")
+	ErrFakeFunds       = errors.New("fake casino balance too low")
+)
+
+// FakeProvider is an in-memory provider for tests: accounts, games and transactions live in
+// maps and slices and every call is recorded
+type FakeProvider struct {
+	name string
+
+	mu           sync.Mutex
+	balances     map[string]float64
+	games        []Game
+	transactions []Transaction
+	references   map[string]bool // Transfer references applied
+	Calls        []string
+}
+
+func NewFakeProvider(name string) *FakeProvider {
+	return &FakeProvider{
+		name:       name,
+		balances:   map[string]float64{},
+		references: map[string]bool{},
+	}
+}
+
+func (f *FakeProvider) Name() string {
+	return f.name
+}
+
+func (f *FakeProvider) record(call string) {
+	f.Calls = append(f.Calls, call)
+}
+
+// HasUser reports whether EnsureUser created the account of username
+func (f *FakeProvider) HasUser(username string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	_, ok := f.balances[username]
+	return ok
+}
+
+// AddGames appends games to the game list
+func (f *FakeProvider) AddGames(games ...Game) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.games = append(f.games, games...)
+}
+
+// AddTransactions appends transactions to the transaction feed
+func (f *FakeProvider) AddTransactions(transactions ...Transaction) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.transactions = append(f.transactions, transactions...)
+}
+
+func (f *FakeProvider) EnsureUser(ctx context.Context, username string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("EnsureUser " + username)
+	if _, ok := f.balances[username]; !ok {
+		f.balances[username] = 0
+	}
+	return nil
+}
+
+func (f *FakeProvider) LaunchLink(ctx context.Context, req LaunchRequest) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("LaunchLink " + req.Username)
+	if _, ok := f.balances[req.Username]; !ok {
+		return "", ErrFakeUnknownUser
+	}
+	return fmt.Sprintf("https://%s.test/launch?vendor=%s&game=%s&user=%s", f.name, req.Vendor, req.GameID, req.Username), nil
+}
+
+func (f *FakeProvider) Balance(ctx context.Context, username string) (float64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("Balance " + username)
+	balance, ok := f.balances[username]
+	if !ok {
+		return 0, ErrFakeUnknownUser
+	}
+	return balance, nil
+}
+
+func (f *FakeProvider) Deposit(ctx context.Context, username string, amount float64, reference string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("Deposit " + username)
+	if _, ok := f.balances[username]; !ok {
+		return ErrFakeUnknownUser
+	}
+	if f.references[reference] {
+		return nil
+	}
+	f.balances[username] += amount
+	f.references[reference] = true
+	return nil
+}
+
+func (f *FakeProvider) Withdraw(ctx context.Context, username string, amount float64, reference string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("Withdraw " + username)
+	balance, ok := f.balances[username]
+	if !ok {
+		return ErrFakeUnknownUser
+	}
+	if f.references[reference] {
+		return nil
+	}
+	if amount > balance {
+		return ErrFakeFunds
+	}
+	f.balances[username] = balance - amount
+	f.references[reference] = true
+	return nil
+}
+
+// TransferApplied reports whether a deposit or withdrawal with reference was applied
+func (f *FakeProvider) TransferApplied(ctx context.Context, username, reference string) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("TransferApplied " + username)
+	if _, ok := f.balances[username]; !ok {
+		return false, ErrFakeUnknownUser
+	}
+	return f.references[reference], nil
+}
+
+func (f *FakeProvider) Games(ctx context.Context, vendor, gameType string) ([]Game, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("Games " + vendor)
+	var games []Game
+	for _, game := range f.games {
+		if (vendor == "" || game.Vendor == vendor) && (gameType == "" || game.Type == gameType) {
+			games = append(games, game)
+		}
+	}
+	return games, nil
+}
+
+func (f *FakeProvider) Transactions(ctx context.Context, start, end time.Time, page, perPage int) (*TransactionPage, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.record("Transactions")
+	var matching []Transaction
+	for _, t := range f.transactions {
+		if !t.CreatedAt.Before(start) && t.CreatedAt.Before(end) {
+			matching = append(matching, t)
+		}
+	}
+
+	result := &TransactionPage{Total: len(matching)}
+	from := (page - 1) * perPage
+	if page < 1 || perPage < 1 || from >= len(matching) {
+		return result, nil
+	}
+	to := from + perPage
+	if to > len(matching) {
+		to = len(matching)
+	}
+	result.Transactions = matching[from:to]
+	return result, nil
+}
@@ -0,0 +1,184 @@
+package casino
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hotbrainy/go-betting/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// DefaultProvider serves the vendors that have no game API row
+const DefaultProvider = "honorlink"
+
+var (
+	ErrUnknownProvider = errors.New("unknown casino provider")
+	ErrVendorDisabled  = errors.New("casino vendor is disabled")
+)
+
+// Provider is a casino aggregator: it hosts the user's casino account, launches the games of
+// its vendors and publishes the bets and wins played on them
+type Provider interface {
+	Name() string
+
+	// EnsureUser creates the casino account of username unless it exists already
+	EnsureUser(ctx context.Context, username string) error
+	LaunchLink(ctx context.Context, req LaunchRequest) (string, error)
+
+	Balance(ctx context.Context, username string) (float64, error)
+	// Deposit moves amount from our ledger to the casino account. reference identifies the
+	// transfer; providers that take it apply a reference once.
+	Deposit(ctx context.Context, username string, amount float64, reference string) error
+	// Withdraw moves amount from the casino account back to our ledger
+	Withdraw(ctx context.Context, username string, amount float64, reference string) error
+
+	Games(ctx context.Context, vendor, gameType string) ([]Game, error)
+	// Transactions returns a page of the transactions created between start and end
+	Transactions(ctx context.Context, start, end time.Time, page, perPage int) (*TransactionPage, error)
+}
+
+// TransferConfirmer is a provider that can tell whether the deposit or withdrawal sent with
+// reference was applied, so that a transfer whose call went unanswered can be settled safely
+type TransferConfirmer interface {
+	TransferApplied(ctx context.Context, username, reference string) (bool, error)
+}
+
+// LaunchRequest asks for a game of Vendor; an empty GameID opens the vendor's lobby
+type LaunchRequest struct {
+	Username string
+	Nickname string
+	Vendor   string
+	GameID   string
+}
+
+// Game is an entry of a provider's game list
+type Game struct {
+	ID        string `json:"id"`
+	Vendor    string `json:"vendor"`
+	Type      string `json:"type"`
+	Title     string `json:"title"`
+	Thumbnail string `json:"thumbnail"`
+}
+
+// GameRef identifies the game a transaction was played on, in the shape stored in
+// CasinoBet.Details
+type GameRef struct {
+	Vendor string `json:"vendor"`
+	Type   string `json:"type"`
+	ID     string `json:"id"`
+	Title  string `json:"title"`
+	Round  string `json:"round"`
+}
+
+// Transaction is a bet, win or other balance change of a casino account. Bets carry a
+// negative amount.
+type Transaction struct {
+	ID            string
+	Username      string
+	Amount        float64
+	Type          string
+	Status        string
+	BalanceBefore float64
+	Game          GameRef
+	CreatedAt     time.Time
+}
+
+type TransactionPage struct {
+	Transactions []Transaction
+	Total        int
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]Provider{}
+)
+
+// Register adds p to the providers game API rows can route vendors to, replacing any
+// provider of the same name
+func Register(p Provider) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[providerKey(p.Name())] = p
+}
+
+// Get returns the registered provider called name
+func Get(name string) (Provider, error) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	p, ok := registry[providerKey(name)]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownProvider, name)
+	}
+	return p, nil
+}
+
+// Default returns the provider of vendors without a game API row
+func Default() (Provider, error) {
+	return Get(DefaultProvider)
+}
+
+// Providers returns the registered providers ordered by name
+func Providers() []Provider {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	providers := make([]Provider, 0, len(registry))
+	for _, p := range registry {
+		providers = append(providers, p)
+	}
+	sort.Slice(providers, func(i, j int) bool {
+		return providers[i].Name() < providers[j].Name()
+	})
+	return providers
+}
+
+func providerKey(name string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(name), " ", ""))
+}
+
+// ResolveProvider picks the provider of vendor from the game API rows: the first enabled row
+// whose GameCompanyName is the vendor names the provider in ApiCompanyName. A vendor whose rows
+// are all disabled is unavailable, a vendor without rows goes to the default provider.
+func ResolveProvider(apis []models.GameAPI, vendor string) (Provider, error) {
+	vendorKey := providerKey(vendor)
+	disabled := false
+	for _, api := range apis {
+		if providerKey(api.GameCompanyName) != vendorKey {
+			continue
+		}
+		if !api.WhetherToUse {
+			disabled = true
+			continue
+		}
+		return Get(api.ApiCompanyName)
+	}
+	if disabled {
+		return nil, fmt.Errorf("%w: %s", ErrVendorDisabled, vendor)
+	}
+	return Default()
+}
+
+// ForVendor returns the provider games of vendor are launched on
+func ForVendor(db *gorm.DB, vendor string) (Provider, error) {
+	var apis []models.GameAPI
+	if err := db.Where("game_company_name <> ''").Order("order_num ASC, id ASC").Find(&apis).Error; err != nil {
+		return nil, err
+	}
+	return ResolveProvider(apis, vendor)
+}
+
+// ProvisionUser creates the casino account of username on every registered provider,
+// returning the errors of the providers that failed
+func ProvisionUser(ctx context.Context, username string) error {
+	var errs []error
+	for _, p := range Providers() {
+		if err := p.EnsureUser(ctx, username); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", p.Name(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,81 @@
+package casino
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hotbrainy/go-betting/backend/internal/models"
+)
+
+func TestResolveProvider(t *testing.T) {
+	fake := NewFakeProvider("fakecasino")
+	Register(fake)
+
+	apis := []models.GameAPI{
+		{ApiCompanyName: "FakeCasino", GameCompanyName: "Evolution", WhetherToUse: true},
+		{ApiCompanyName: "FakeCasino", GameCompanyName: "pgsoft", WhetherToUse: false},
+		{ApiCompanyName: "Nowhere", GameCompanyName: "jili", WhetherToUse: true},
+	}
+
+	if p, err := ResolveProvider(apis, "evolution"); err != nil || p != Provider(fake) {
+		t.Fatalf("evolution: got %v, %v, want the fake provider", p, err)
+	}
+	if _, err := ResolveProvider(apis, "pgsoft"); !errors.Is(err, ErrVendorDisabled) {
+		t.Fatalf("pgsoft: got %v, want ErrVendorDisabled", err)
+	}
+	if _, err := ResolveProvider(apis, "jili"); !errors.Is(err, ErrUnknownProvider) {
+		t.Fatalf("jili: got %v, want ErrUnknownProvider", err)
+	}
+	if p, err := ResolveProvider(apis, "vivo"); err != nil || p.Name() != DefaultProvider {
+		t.Fatalf("vivo: got %v, %v, want the default provider", p, err)
+	}
+}
+
+func TestFakeProvider(t *testing.T) {
+	ctx := context.Background()
+	fake := NewFakeProvider("fake")
+
+	if _, err := fake.LaunchLink(ctx, LaunchRequest{Username: "alice"}); !errors.Is(err, ErrFakeUnknownUser) {
+		t.Fatalf("launch before provisioning: got %v, want ErrFakeUnknownUser", err)
+	}
+	if err := fake.EnsureUser(ctx, "alice"); err != nil {
+		t.Fatal(err)
+	}
+	if err := fake.Deposit(ctx, "alice", 150, "t1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := fake.Deposit(ctx, "alice", 150, "t1"); err != nil {
+		t.Fatal(err)
+	}
+	if balance, _ := fake.Balance(ctx, "alice"); balance != 150 {
+		t.Fatalf("balance after deposit retried = %v, want 150", balance)
+	}
+	if err := fake.Withdraw(ctx, "alice", 100, "t2"); err != nil {
+		t.Fatal(err)
+	}
+	if balance, _ := fake.Balance(ctx, "alice"); balance != 50 {
+		t.Fatalf("balance after withdrawal = %v, want 50", balance)
+	}
+	if err := fake.Withdraw(ctx, "alice", 100, "t3"); !errors.Is(err, ErrFakeFunds) {
+		t.Fatalf("withdrawal above balance: got %v, want ErrFakeFunds", err)
+	}
+	for reference, want := range map[string]bool{"t1": true, "t2": true, "t3": false} {
+		if applied, err := fake.TransferApplied(ctx, "alice", reference); err != nil || applied != want {
+			t.Fatalf("transfer %s applied = %v, %v, want %v", reference, applied, err, want)
+		}
+	}
+
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 5; i++ {
+		fake.AddTransactions(Transaction{ID: string(rune('a' + i)), Username: "alice", Amount: -10, Type: "bet", CreatedAt: start.Add(time.Duration(i) * time.Minute)})
+	}
+	page, err := fake.Transactions(ctx, start, start.Add(4*time.Minute), 2, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page.Total != 4 || len(page.Transactions) != 1 || page.Transactions[0].ID != "d" {
+		t.Fatalf("page 2 = %+v, want the fourth of 4 transactions", page)
+	}
+}
@@ -0,0 +1,38 @@
+package fetcher
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hotbrainy/go-betting/backend/db/initializers"
+	"github.com/hotbrainy/go-betting/backend/internal/services"
+)
+
+const casinoGameSyncInterval = time.Hour
+
+// StartCasinoGameSync refreshes the casino game catalog from the providers every hour
+func StartCasinoGameSync() {
+	go func() {
+		fmt.Println("🚀 Starting casino game catalog sync every hour...")
+
+		// Let the HonorLink transaction sync go first
+		time.Sleep(time.Minute)
+		syncCasinoGames()
+
+		ticker := time.NewTicker(casinoGameSyncInterval)
+		defer ticker.Stop()
+		for range ticker.C {
+			syncCasinoGames()
+		}
+	}()
+}
+
+func syncCasinoGames() {
+	synced, err := services.SyncCasinoCatalog(context.Background(), initializers.DB)
+	if err != nil {
+		fmt.Printf("❌ Casino game catalog sync failed after %d games: %v\n", synced, err)
+		return
+	}
+	fmt.Printf("✅ Casino game catalog synced: %d games\n", synced)
+}
@@ -0,0 +1,30 @@
+package fetcher
+
+import (
+	"fmt"
+
+	"github.com/hotbrainy/go-betting/backend/db/initializers"
+	"github.com/hotbrainy/go-betting/backend/internal/services"
+)
+
+// StartCasinoRoundBackfill fills the game columns of, and links to their rounds, the casino
+// bets recorded before those were kept at ingest, once per start
+func StartCasinoRoundBackfill() {
+	go func() {
+		filled, err := services.BackfillCasinoBetGames(initializers.DB)
+		if err != nil {
+			fmt.Printf("❌ Casino bet game backfill failed: %v\n", err)
+		} else if filled > 0 {
+			fmt.Printf("✅ Casino bet game backfill filled %d bets\n", filled)
+		}
+
+		linked, err := services.BackfillCasinoRounds(initializers.DB)
+		if err != nil {
+			fmt.Printf("❌ Casino round backfill stopped after %d bets: %v\n", linked, err)
+			return
+		}
+		if linked > 0 {
+			fmt.Printf("✅ Casino round backfill linked %d bets\n", linked)
+		}
+	}()
+}
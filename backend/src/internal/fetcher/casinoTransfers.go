@@ -0,0 +1,30 @@
+package fetcher
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hotbrainy/go-betting/backend/db/initializers"
+	"github.com/hotbrainy/go-betting/backend/internal/services"
+)
+
+const casinoTransferRecoveryInterval = time.Minute
+
+// StartCasinoTransferRecovery finishes or compensates casino transfers left half-done by a
+// crash or a failed provider call, every minute
+func StartCasinoTransferRecovery() {
+	go func() {
+		ticker := time.NewTicker(casinoTransferRecoveryInterval)
+		defer ticker.Stop()
+		for range ticker.C {
+			settled, err := services.RecoverCasinoTransfers(context.Background(), initializers.DB)
+			if err != nil {
+				fmt.Printf("❌ Casino transfer recovery: %v\n", err)
+			}
+			if settled > 0 {
+				fmt.Printf("✅ Casino transfer recovery settled %d transfers\n", settled)
+			}
+		}
+	}()
+}
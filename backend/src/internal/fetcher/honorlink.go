@@ -1,150 +1,32 @@
 package fetcher
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
 	"fmt"
-	"io"
 	"math"
-	"net/http"
-	"net/url"
-	"os"
-	"strconv"
-	"strings"
+	"sync"
 	"time"
 
 	"github.com/hotbrainy/go-betting/backend/db/initializers"
+	"github.com/hotbrainy/go-betting/backend/internal/casino"
 	"github.com/hotbrainy/go-betting/backend/internal/models"
+	"github.com/hotbrainy/go-betting/backend/internal/services"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
-type User struct {
-	Username string `json:"username"`
-}
-
-type HonorLinkTransaction struct {
-	ID            interface{} `json:"id"` // Can be string or number
-	UserID        string      `json:"userId"`
-	Username      string      `json:"username"`
-	Amount        float64     `json:"amount"`
-	Type          string      `json:"type"`
-	Status        string      `json:"status"`
-	User          User        `json:"user"`
-	Details       Details     `json:"details"`
-	CreatedAt     time.Time   `json:"createdAt"`
-	UpdatedAt     time.Time   `json:"updatedAt"`
-	BalanceBefore float64     `json:"before"`
-}
-
-type Details struct {
-	Game Game `json:"game"`
-}
-
-type Game struct {
-	Vendor string `json:"vendor"`
-	Type   string `json:"type"`
-	Id     string `json:"id"`
-	Title  string `json:"title"`
-	Round  string `json:"round"`
-}
-
-// GetIDString returns the ID as a string, handling both string and numeric IDs
-func (t *HonorLinkTransaction) GetIDString() string {
-	switch v := t.ID.(type) {
-	case string:
-		return v
-	case float64:
-		// Use fmt.Sprintf with %.0f to avoid scientific notation for large numbers
-		return fmt.Sprintf("%.0f", v)
-	case int:
-		return strconv.Itoa(v)
-	case int64:
-		return strconv.FormatInt(v, 10)
-	default:
-		return fmt.Sprintf("%v", v)
-	}
-}
-
-type HonorLinkResponse struct {
-	Success bool                   `json:"success"`
-	Data    []HonorLinkTransaction `json:"data"`
-	Total   int                    `json:"total"`
-	Page    int                    `json:"page"`
-	PerPage int                    `json:"perPage"`
-}
-
+// HonorLinkFetcher records the bets and wins of HonorLink's transaction feed
 type HonorLinkFetcher struct {
-	BaseURL string
-	Token   string
-	Client  *http.Client
+	Provider casino.Provider
 }
 
 func NewHonorLinkFetcher() *HonorLinkFetcher {
-	token := os.Getenv("HONORLINK_TOKEN")
-	if token == "" {
-		token = "srDiqct6lH61a0zHNKPUu0IwE0mg7Ht38sALu3oWb5bf8e9d" // fallback
-	}
-
-	return &HonorLinkFetcher{
-		BaseURL: "https://api.honorlink.org/api/transactions",
-		Token:   token,
-		Client: &http.Client{
-			Timeout: 40 * time.Second, // Increased timeout to 60 seconds
-		},
-	}
+	return &HonorLinkFetcher{Provider: casino.NewHonorLink()}
 }
 
-func (h *HonorLinkFetcher) FetchTransactions(start, end time.Time, page, perPage int) (*HonorLinkResponse, error) {
-	// Create URL with query parameters
-	baseURL, err := url.Parse(h.BaseURL)
-	if err != nil {
-		return nil, fmt.Errorf("invalid base URL: %v", err)
-	}
-
-	params := url.Values{}
-	params.Add("start", start.Format("2006-01-02 15:04:05"))
-	params.Add("end", end.Format("2006-01-02 15:04:05"))
-	params.Add("page", fmt.Sprintf("%d", page))
-	params.Add("perPage", fmt.Sprintf("%d", perPage))
-
-	baseURL.RawQuery = params.Encode()
-
-	// Create request
-	req, err := http.NewRequest("GET", baseURL.String(), nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %v", err)
-	}
-
-	// Add authorization header and other headers for better connection
-	req.Header.Add("Authorization", "Bearer "+h.Token)
-	req.Header.Add("Content-Type", "application/json")
-	req.Header.Add("User-Agent", "HonorLink-Fetcher/1.0")
-	req.Header.Add("Accept", "application/json")
-	req.Header.Add("Connection", "keep-alive")
-
-	// Make request
-	resp, err := h.Client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to make request: %v", err)
-	}
-	defer resp.Body.Close()
-
-	// Read response body
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %v", err)
-	}
-
-	// Check if response is successful
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
-	}
-
-	// Parse response
-	var response HonorLinkResponse
-	if err := json.Unmarshal(body, &response); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %v", err)
-	}
-
-	return &response, nil
+func (h *HonorLinkFetcher) FetchTransactions(start, end time.Time, page, perPage int) (*casino.TransactionPage, error) {
+	return h.Provider.Transactions(context.Background(), start, end, page, perPage)
 }
 
 func (h *HonorLinkFetcher) StartPeriodicFetching() {
@@ -155,11 +37,13 @@ func (h *HonorLinkFetcher) StartPeriodicFetching() {
 
 		// Initial fetch with a slight delay to allow system startup
 		time.Sleep(10 * time.Second)
-		h.fetchAndLogTransactions()
+		h.syncTransactions()
+		h.runPendingBackfills()
 
 		// Periodic fetching
 		for range ticker.C {
-			h.fetchAndLogTransactions()
+			h.syncTransactions()
+			h.runPendingBackfills()
 		}
 	}()
 }
@@ -167,234 +51,365 @@ func (h *HonorLinkFetcher) StartPeriodicFetching() {
 // ManualFetch allows manual triggering of the fetch for testing
 func (h *HonorLinkFetcher) ManualFetch() {
 	fmt.Println("🔄 Manual fetch triggered...")
-	h.fetchAndLogTransactions()
+	h.syncTransactions()
 }
 
-func (h *HonorLinkFetcher) fetchAndLogTransactions() {
-	// Use UTC timezone
-	loc := time.UTC
+const (
+	honorLinkPerPage   = 1000
+	honorLinkOverlap   = time.Minute      // Re-read before the cursor for transactions published late
+	honorLinkLookback  = 3 * time.Minute  // Where the very first sync starts
+	honorLinkMaxWindow = 30 * time.Minute // Longest range requested at once when catching up
+	honorLinkRetries   = 3
+)
 
-	// Set time range to last 3 minutes in UTC timezone
-	now := time.Now().In(loc)
-	end := now                         // Current time UTC
-	start := end.Add(-2 * time.Minute) // 3 minutes ago in UTC
-	fmt.Println(start, end, "date------------- (UTC Time)")
+// honorLinkSyncMu keeps the periodic sync and backfills of this instance from fetching at the
+// same time; across instances, the unique casino bet TransID keeps a transaction recorded once
+var honorLinkSyncMu sync.Mutex
 
-	// Retry logic for API calls with fallback time ranges
-	var response *HonorLinkResponse
+// fetchPage fetches one page of transactions, retrying with a growing delay
+func (h *HonorLinkFetcher) fetchPage(start, end time.Time, page int) (*casino.TransactionPage, error) {
+	var response *casino.TransactionPage
 	var err error
-	maxRetries := 3
-	timeRanges := []time.Duration{3 * time.Minute}
-
-	for attempt := 1; attempt <= maxRetries; attempt++ {
-		// Use different time ranges for each retry
-		var currentStart time.Time
-		if attempt <= len(timeRanges) {
-			currentStart = end.Add(-timeRanges[attempt-1])
-		} else {
-			currentStart = start
+	for attempt := 1; attempt <= honorLinkRetries; attempt++ {
+		response, err = h.FetchTransactions(start, end, page, honorLinkPerPage)
+		if err == nil {
+			return response, nil
 		}
 
-		timeRangeIndex := attempt - 1
-		if timeRangeIndex >= len(timeRanges) {
-			timeRangeIndex = len(timeRanges) - 1
+		fmt.Printf("❌ HonorLink page %d attempt %d/%d failed: %v\n", page, attempt, honorLinkRetries, err)
+		if attempt < honorLinkRetries {
+			time.Sleep(time.Duration(attempt*attempt) * time.Second)
 		}
-		fmt.Printf("🔄 Attempt %d/%d: Fetching %v of data...\n", attempt, maxRetries, timeRanges[timeRangeIndex])
-		response, err = h.FetchTransactions(currentStart, end, 1, 1000) // Reduced perPage to 50
-		if err == nil {
-			// Update start time if we had to use a smaller range
-			if attempt > 1 {
-				start = currentStart
-			}
-			break // Success, exit retry loop
+	}
+	return nil, err
+}
+
+// fetchWindow fetches every page of transactions created between start and end
+func (h *HonorLinkFetcher) fetchWindow(start, end time.Time) ([]casino.Transaction, error) {
+	var transactions []casino.Transaction
+	for page := 1; ; page++ {
+		response, err := h.fetchPage(start, end, page)
+		if err != nil {
+			return nil, err
 		}
+		transactions = append(transactions, response.Transactions...)
 
-		fmt.Printf("❌ Attempt %d/%d failed: %v\n", attempt, maxRetries, err)
-		if attempt < maxRetries {
-			// Wait before retrying (exponential backoff)
-			waitTime := time.Duration(attempt*attempt) * time.Second
-			fmt.Printf("⏳ Waiting %v before retry...\n", waitTime)
-			time.Sleep(waitTime)
+		if len(response.Transactions) < honorLinkPerPage || page*honorLinkPerPage >= response.Total {
+			return transactions, nil
 		}
 	}
+}
 
+// syncWindow records the bets and wins created between start and end that are not recorded yet.
+// Windows may overlap; transactions are deduplicated on their casino bet TransID. Transactions
+// of no local user are set aside; the first one that cannot be recorded otherwise fails the
+// window, so that its cursor does not move past it.
+func (h *HonorLinkFetcher) syncWindow(start, end time.Time) (fetched, processed int, err error) {
+	transactions, err := h.fetchWindow(start.UTC(), end.UTC())
 	if err != nil {
-		fmt.Printf("❌ Failed to fetch HonorLink transactions after %d attempts: %v\n", maxRetries, err)
-		return
+		return 0, 0, err
 	}
 
-	// Log the results
-	fmt.Printf("✅ HonorLink API Response:\n")
-	fmt.Printf("   Time Range: %s to %s (UTC Time)\n", start.Format("2006-01-02 15:04:05"), end.Format("2006-01-02 15:04:05"))
-	fmt.Printf("   Success: %t\n", response.Success)
-	fmt.Printf("   Total Transactions: %d\n", response.Total)
-	fmt.Printf("   end: %s\n", now.Format("2006-01-02 15:04:05"))
-	fmt.Printf("   start: %s\n", start.Format("2006-01-02 15:04:05"))
-	fmt.Printf(" ✅✅✅  Transactions in this page: %d\n", len(response.Data))
-
-	if len(response.Data) > 0 {
-		fmt.Printf("   Sample transactions:\n")
-		for i, transaction := range response.Data {
-			// if i >= 5 { // Show only first 5 transactions
-			// 	break
-			// }
-			// check transaction id is exist in transaction table explation
-			var transactionDB models.Transaction
-			transactionIDStr := transaction.GetIDString()
-			if err := initializers.DB.Where("explation = ?", transactionIDStr).First(&transactionDB).Error; err == nil {
-				fmt.Printf("❌ Transaction with explation %s already exists\n", transactionIDStr)
-				continue
-			} else {
-				fmt.Printf("%d. ID: %s, UserID: %s, Username: '%s', Amount: %.2f, Type: %s, Status: %s\n",
-					i+1, transactionIDStr, transaction.UserID, transaction.User.Username, transaction.Amount, transaction.Type, transaction.Status)
-				h.processTransaction(transaction)
-			}
+	for _, transaction := range transactions {
+		if transaction.Type != "bet" && transaction.Type != "win" {
+			continue
+		}
+
+		var synced int64
+		if err := initializers.DB.Model(&models.CasinoBet{}).
+			Where("trans_id = ?", transaction.ID).
+			Count(&synced).Error; err != nil {
+			return len(transactions), processed, err
+		}
+		if synced > 0 {
+			continue
+		}
+
+		recorded, err := h.processTransaction(transaction)
+		if err != nil {
+			return len(transactions), processed, err
+		}
+		if recorded {
+			processed++
 		}
-	} else {
-		fmt.Printf("   No transactions found in the specified time range\n")
 	}
 
-	fmt.Printf("   Fetched at: %s (UTC Time)\n", time.Now().In(loc).Format("2006-01-02 15:04:05"))
-	fmt.Println("   " + strings.Repeat("-", 50))
+	return len(transactions), processed, nil
 }
 
+// syncTransactions catches up from the persisted cursor to now, a window at a time, moving the
+// cursor after every window so that a failure resumes where it stopped
+func (h *HonorLinkFetcher) syncTransactions() {
+	honorLinkSyncMu.Lock()
+	defer honorLinkSyncMu.Unlock()
 
-// processTransaction handles individual HonorLink transactions
-func (h *HonorLinkFetcher) processTransaction(hlTransaction HonorLinkTransaction) {
-	// Find user by matching transaction.Username or UserID with user.userid field
-	var user models.User
-	var err error
+	now := time.Now().UTC()
 
-	// Try to find user by Username first, then by UserID if Username is empty
-	useridToSearch := hlTransaction.User.Username
-	if useridToSearch == "" {
-		useridToSearch = hlTransaction.UserID
+	var state models.HonorLinkSyncState
+	if err := initializers.DB.First(&state).Error; err != nil {
+		state.Cursor = now.Add(-honorLinkLookback)
+		if err := initializers.DB.Create(&state).Error; err != nil {
+			fmt.Printf("❌ Failed to create HonorLink sync state: %v\n", err)
+			return
+		}
 	}
 
-	if useridToSearch == "" {
-		fmt.Printf("❌ Both Username and UserID are empty for transaction ID %s\n", hlTransaction.GetIDString())
-		return
+	state.LastRunAt = &now
+	state.LastFetched = 0
+	state.LastProcessed = 0
+
+	start := state.Cursor.Add(-honorLinkOverlap)
+	for start.Before(now) {
+		end := start.Add(honorLinkMaxWindow)
+		if end.After(now) {
+			end = now
+		}
+
+		fetched, processed, err := h.syncWindow(start, end)
+		state.LastFetched += fetched
+		state.LastProcessed += processed
+		if err != nil {
+			state.LastError = err.Error()
+			initializers.DB.Save(&state)
+			fmt.Printf("❌ HonorLink sync stopped at %s: %v\n", start.Format("2006-01-02 15:04:05"), err)
+			return
+		}
+
+		state.Cursor = end
+		if err := initializers.DB.Save(&state).Error; err != nil {
+			fmt.Printf("❌ Failed to save HonorLink sync cursor: %v\n", err)
+			return
+		}
+		start = end
 	}
 
-	if err = initializers.DB.Where("userid = ?", useridToSearch).First(&user).Error; err != nil {
-		fmt.Printf("❌ Error finding user with userid %s: %v\n", useridToSearch, err)
+	state.LastSuccessAt = &now
+	state.LastError = ""
+	if err := initializers.DB.Save(&state).Error; err != nil {
+		fmt.Printf("❌ Failed to save HonorLink sync state: %v\n", err)
 		return
 	}
 
-	// Get user's profile to check current balance
-	var profile models.Profile
-	if err := initializers.DB.Where("user_id = ?", user.ID).First(&profile).Error; err != nil {
-		fmt.Printf("❌ Error finding user profile for ID %d: %v\n", user.ID, err)
+	fmt.Printf("✅ HonorLink synced to %s: %d fetched, %d recorded\n",
+		state.Cursor.Format("2006-01-02 15:04:05"), state.LastFetched, state.LastProcessed)
+}
+
+// runPendingBackfills works through the backfills requested by admins, oldest first.
+// A backfill interrupted by a restart resumes from its progress.
+func (h *HonorLinkFetcher) runPendingBackfills() {
+	honorLinkSyncMu.Lock()
+	defer honorLinkSyncMu.Unlock()
+
+	var backfills []models.HonorLinkBackfill
+	if err := initializers.DB.Where("status IN ?", []string{"pending", "running"}).
+		Order("id ASC").Find(&backfills).Error; err != nil {
+		fmt.Printf("❌ Failed to load HonorLink backfills: %v\n", err)
 		return
 	}
 
-	//insert the bet history on CasinoBet table
-	if hlTransaction.Type == "bet" || hlTransaction.Type == "win" {
-		// Convert Unix timestamp to proper uint - use a reasonable timestamp
-		var bettingTime uint
-		timestamp := hlTransaction.CreatedAt.Unix()
-
-		// Ensure timestamp is within reasonable bounds for uint
-		if timestamp < 0 {
-			bettingTime = uint(time.Now().Unix())
-		} else if timestamp > math.MaxInt32 {
-			// If timestamp is too large, use current time
-			bettingTime = uint(time.Now().Unix())
-		} else {
-			bettingTime = uint(timestamp)
-		}
+	for i := range backfills {
+		backfill := &backfills[i]
+		backfill.Status = "running"
 
-		// Check if casino bet already exists to avoid duplicates
-		var existingCasinoBet models.CasinoBet
-		if err := initializers.DB.Where("trans_id = ?", hlTransaction.GetIDString()).First(&existingCasinoBet).Error; err == nil {
-			fmt.Printf("❌ Casino bet with TransID %s already exists\n", hlTransaction.GetIDString())
-		} else {
-			casinoBet := models.CasinoBet{
-				UserID:        user.ID,
-				GameID:        0, // Set default GameID
-				Amount:        hlTransaction.Amount,
-				Type:          hlTransaction.Type,
-				GameName:      hlTransaction.Details.Game.Vendor + "|" + hlTransaction.Details.Game.Type,
-				TransID:       hlTransaction.GetIDString(),
-				Details:       hlTransaction.Details,
-				BeforeAmount:  hlTransaction.BalanceBefore,
-				AfterAmount:   hlTransaction.BalanceBefore + hlTransaction.Amount,
-				Status:        hlTransaction.Status,
-				BettingTime:   bettingTime,
-				WinningAmount: 0, // Set default WinningAmount
+		start := backfill.Start
+		if backfill.Progress.After(start) {
+			start = backfill.Progress
+		}
+		for start.Before(backfill.End) {
+			end := start.Add(honorLinkMaxWindow)
+			if end.After(backfill.End) {
+				end = backfill.End
 			}
-			if err := initializers.DB.Create(&casinoBet).Error; err != nil {
-				fmt.Printf("❌ Error creating casino bet record: %v\n", err)
-				return
+
+			fetched, processed, err := h.syncWindow(start, end)
+			backfill.Fetched += fetched
+			backfill.Processed += processed
+			if err != nil {
+				backfill.Status = "failed"
+				backfill.Error = err.Error()
+				break
 			}
+
+			backfill.Progress = end
+			initializers.DB.Save(backfill)
+			start = end
 		}
+
+		if backfill.Status == "running" {
+			backfill.Status = "done"
+		}
+		if err := initializers.DB.Save(backfill).Error; err != nil {
+			fmt.Printf("❌ Failed to save HonorLink backfill %d: %v\n", backfill.ID, err)
+			continue
+		}
+		fmt.Printf("✅ HonorLink backfill %d %s: %d fetched, %d recorded\n",
+			backfill.ID, backfill.Status, backfill.Fetched, backfill.Processed)
 	}
+}
 
-	// Calculate balance before and after
-	var balanceBefore float64
-	var gameType string
-	balanceBefore = hlTransaction.BalanceBefore
-	if hlTransaction.Type == "bet" {
-		gameType = "bet"
-	} else if hlTransaction.Type == "win" {
-		gameType = "win"
+// processTransaction records a HonorLink bet or win: its casino bet, round, ledger row, wager,
+// statistics and rolling are written in one transaction, so that a failure leaves nothing
+// behind and the transaction is picked up again by the next sync of its window. It reports
+// false when the transaction was already recorded, by this or another instance, or names no
+// local user and was set aside.
+func (h *HonorLinkFetcher) processTransaction(hlTransaction casino.Transaction) (bool, error) {
+	if hlTransaction.Username == "" {
+		return false, recordUnmatchedTransaction(hlTransaction, "no username")
+	}
+
+	// Find user by matching transaction.Username with user.userid field
+	var user models.User
+	if err := initializers.DB.Where("userid = ?", hlTransaction.Username).First(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, recordUnmatchedTransaction(hlTransaction, "no local user")
+		}
+		return false, fmt.Errorf("transaction %s: finding user %s: %w", hlTransaction.ID, hlTransaction.Username, err)
 	}
 
-	fmt.Println(hlTransaction.CreatedAt, "hlTransaction.CreatedAt")
+	// Convert Unix timestamp to proper uint - use a reasonable timestamp
+	var bettingTime uint
+	timestamp := hlTransaction.CreatedAt.Unix()
+	if timestamp < 0 || timestamp > math.MaxInt32 {
+		bettingTime = uint(time.Now().Unix())
+	} else {
+		bettingTime = uint(timestamp)
+	}
 
-	if hlTransaction.Type == "bet" || hlTransaction.Type == "win" {
-		//Create transaction record
-		transactionIDStr := hlTransaction.GetIDString()
-		transaction := models.Transaction{
+	recorded := false
+	var round *models.CasinoRound
+	err := initializers.DB.Transaction(func(tx *gorm.DB) error {
+		casinoBet := models.CasinoBet{
 			UserID:        user.ID,
+			GameID:        0, // Set default GameID
 			Amount:        hlTransaction.Amount,
-			Type:          gameType,
-			Shortcut:      hlTransaction.Details.Game.Vendor + "|" + hlTransaction.Details.Game.Type,
-			Explation:     transactionIDStr,
-			BalanceBefore: balanceBefore,
-			BalanceAfter:  balanceBefore + hlTransaction.Amount,
-			Status:        "success",
-			TransactionAt:     hlTransaction.CreatedAt,
+			Type:          hlTransaction.Type,
+			GameName:      hlTransaction.Game.Vendor + "|" + hlTransaction.Game.Type,
+			TransID:       hlTransaction.ID,
+			BeforeAmount:  hlTransaction.BalanceBefore,
+			AfterAmount:   hlTransaction.BalanceBefore + hlTransaction.Amount,
+			Status:        hlTransaction.Status,
+			BettingTime:   bettingTime,
+			WinningAmount: 0, // Set default WinningAmount
+			PlayedAt:      hlTransaction.CreatedAt,
+		}
+		if casinoBet.PlayedAt.IsZero() {
+			casinoBet.PlayedAt = time.Now()
 		}
+		services.SetCasinoBetGame(&casinoBet, hlTransaction.Game)
 
-		// Save transaction to database
-		if err := initializers.DB.Create(&transaction).Error; err != nil {
-			fmt.Printf("❌ Error creating transaction record: %v\n", err)
-			return
+		// TransID is unique: a transaction another instance recorded first is skipped
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&casinoBet)
+		if result.Error != nil {
+			return fmt.Errorf("creating casino bet: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return nil
 		}
 
-		rollingGoldAmount := math.Abs(hlTransaction.Amount * float64(user.Live) / 100)
-		rollValue := float64(profile.Roll) + rollingGoldAmount
-		if err := initializers.DB.Model(&profile).Update("roll", rollValue).Error; err != nil {
-			fmt.Printf("❌ Error updating user roll value: %v\n", err)
-			return
+		var err error
+		if round, err = services.RecordCasinoRound(tx, h.Provider.Name(), &casinoBet); err != nil {
+			return fmt.Errorf("recording casino round: %w", err)
 		}
-		transactionRolling := models.Transaction{
+
+		if err := tx.Create(&models.Transaction{
 			UserID:        user.ID,
-			Amount:        rollingGoldAmount,
-			Type:          "Rolling",
-			Shortcut:      hlTransaction.Details.Game.Vendor + "|" + hlTransaction.Details.Game.Type,
-			Explation:     transactionIDStr + "_rolling",
-			BalanceBefore: float64(profile.Roll),
-			BalanceAfter:  float64(profile.Roll) + rollingGoldAmount,
+			Amount:        hlTransaction.Amount,
+			Type:          hlTransaction.Type,
+			Shortcut:      hlTransaction.Game.Vendor + "|" + hlTransaction.Game.Type,
+			Explation:     hlTransaction.ID,
+			BalanceBefore: hlTransaction.BalanceBefore,
+			BalanceAfter:  hlTransaction.BalanceBefore + hlTransaction.Amount,
 			Status:        "success",
-			TransactionAt:     hlTransaction.CreatedAt,
+			TransactionAt: hlTransaction.CreatedAt,
+		}).Error; err != nil {
+			return fmt.Errorf("creating transaction record: %w", err)
 		}
 
 		if hlTransaction.Type == "bet" {
 			// plus the betting amount to profile wager amount
-			if err := initializers.DB.Model(&profile).Update("wager", profile.Wager + math.Abs(hlTransaction.Amount)).Error; err != nil {
-				fmt.Printf("❌ Error updating user wager value: %v\n", err)
-				return
+			if err := tx.Model(&models.Profile{}).Where("user_id = ?", user.ID).
+				Update("wager", gorm.Expr("wager + ?", math.Abs(hlTransaction.Amount))).Error; err != nil {
+				return fmt.Errorf("updating wager: %w", err)
 			}
 		}
 
-		if err := initializers.DB.Create(&transactionRolling).Error; err != nil {
-			fmt.Printf("❌ Error creating transaction record: %v\n", err)
-			return
+		// Statistics of the game's category, and rolling on the stake at its rate
+		if err := services.AccrueCasinoPlay(tx, user.ID, hlTransaction.Type, hlTransaction.Game,
+			hlTransaction.Amount, hlTransaction.ID, hlTransaction.CreatedAt); err != nil {
+			return fmt.Errorf("accruing casino play: %w", err)
+		}
+
+		// Set aside by an earlier sync, before its user existed
+		if err := tx.Unscoped().Where("transaction_id = ?", hlTransaction.ID).
+			Delete(&models.HonorLinkUnmatchedTransaction{}).Error; err != nil {
+			return fmt.Errorf("clearing unmatched transaction: %w", err)
 		}
 
-		fmt.Printf("✅ Successfully processed HonorLink transaction: ID=%s, User=%d, Amount=%.2f\n",
-			hlTransaction.GetIDString(), user.ID, hlTransaction.Amount)
+		recorded = true
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("transaction %s: %w", hlTransaction.ID, err)
+	}
+	if !recorded {
+		return false, nil
+	}
+
+	if hlTransaction.Type == "bet" {
+		alertCasinoLimits(user.ID, round)
+	}
+
+	fmt.Printf("✅ Successfully processed HonorLink transaction: ID=%s, User=%d, Amount=%.2f\n",
+		hlTransaction.ID, user.ID, hlTransaction.Amount)
+	return true, nil
+}
+
+// recordUnmatchedTransaction sets aside a transaction of no local user so that it does not hold
+// up the sync, alerting admins the first time it is seen
+func recordUnmatchedTransaction(hlTransaction casino.Transaction, reason string) error {
+	unmatched := models.HonorLinkUnmatchedTransaction{
+		TransactionID: hlTransaction.ID,
+		Username:      hlTransaction.Username,
+		Type:          hlTransaction.Type,
+		Amount:        hlTransaction.Amount,
+		Vendor:        hlTransaction.Game.Vendor,
+		GameType:      hlTransaction.Game.Type,
+		GameID:        hlTransaction.Game.ID,
+		Reason:        reason,
+		PlayedAt:      hlTransaction.CreatedAt,
+	}
+	result := initializers.DB.Clauses(clause.OnConflict{DoNothing: true}).Create(&unmatched)
+	if result.Error != nil {
+		return fmt.Errorf("transaction %s: recording unmatched transaction: %w", hlTransaction.ID, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil
+	}
+
+	fmt.Printf("⚠️ Skipped HonorLink transaction %s of %q: %s\n", hlTransaction.ID, hlTransaction.Username, reason)
+
+	title := "Unmatched Casino Transaction"
+	message := fmt.Sprintf("HonorLink %s %s of %.2f for %q was skipped: %s",
+		hlTransaction.Type, hlTransaction.ID, hlTransaction.Amount, hlTransaction.Username, reason)
+	if _, err := services.CreateAlert(initializers.DB, "casinoUnmatched", title, message, unmatched.ID, "/admin/casino/honorlink"); err != nil {
+		fmt.Printf("Error creating unmatched casino transaction alert: %v\n", err)
+	}
+	return nil
+}
+
+// alertCasinoLimits alerts admins of the casino limits the user's play went over. HonorLink
+// settles bets without asking us, so its play can only be checked after the fact.
+func alertCasinoLimits(userID uint, round *models.CasinoRound) {
+	var roundStake float64
+	if round != nil {
+		roundStake = -round.BetAmount
+	}
+
+	breaches, err := services.CasinoLimitBreaches(initializers.DB, userID, roundStake)
+	if err != nil {
+		fmt.Printf("❌ Error checking casino limits of user %d: %v\n", userID, err)
+	}
+	for _, breach := range breaches {
+		services.AlertCasinoLimitBreach(initializers.DB, breach, "exceeded")
 	}
 }
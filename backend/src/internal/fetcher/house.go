@@ -0,0 +1,78 @@
+package fetcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hotbrainy/go-betting/backend/db/initializers"
+	"github.com/hotbrainy/go-betting/backend/internal/minigame"
+	"github.com/hotbrainy/go-betting/backend/internal/services"
+)
+
+// HouseResultProvider draws an in-house powerball game with commit-reveal seeds
+type HouseResultProvider struct {
+	gameType string
+}
+
+// NewHouseResultProvider returns the provider of the in-house game gameType
+func NewHouseResultProvider(gameType string) *HouseResultProvider {
+	return &HouseResultProvider{gameType: gameType}
+}
+
+// GameType implements MiniGameResultProvider
+func (h *HouseResultProvider) GameType() string {
+	return h.gameType
+}
+
+// FetchLatest implements MiniGameResultProvider. It also commits the seed of the running
+// round, so every round is committed before it is drawn.
+func (h *HouseResultProvider) FetchLatest(ctx context.Context) (*EOSPowerballResult, error) {
+	current, err := minigame.RoundAt(h.gameType, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if _, err := services.HouseRoundSeed(initializers.DB, current); err != nil {
+		fmt.Printf("⚠️ Failed to commit %s round %d seed: %v\n", h.gameType, current.Round, err)
+	}
+
+	return h.FetchRound(ctx, current.Previous())
+}
+
+// FetchRound implements MiniGameResultProvider
+func (h *HouseResultProvider) FetchRound(ctx context.Context, round minigame.Round) (*EOSPowerballResult, error) {
+	_, draw, err := services.DrawHouseRound(initializers.DB, round)
+	if err != nil {
+		if errors.Is(err, services.ErrHouseRoundNotDrawn) || errors.Is(err, services.ErrHouseSeedNotPresent) {
+			return nil, ErrRoundUnavailable
+		}
+		return nil, err
+	}
+	return NewHouseResult(round, draw), nil
+}
+
+// NewHouseResult describes an in-house draw in the EOS Powerball result shape, so that bet
+// options and settlement treat both alike
+func NewHouseResult(round minigame.Round, draw minigame.HouseDraw) *EOSPowerballResult {
+	described := draw.MiniGameDraw(round)
+
+	balls := make([]interface{}, 0, len(draw.Balls)+1)
+	for _, ball := range draw.Balls {
+		balls = append(balls, ball)
+	}
+	balls = append(balls, draw.PowerBall)
+
+	return &EOSPowerballResult{
+		Date:           round.Date,
+		DateRound:      int(round.Round),
+		Ball:           balls,
+		PowBallOE:      described.PowBallOddEven,
+		PowBallUnover:  described.PowBallUnderOver,
+		DefBallSum:     fmt.Sprintf("%d", described.DefBallSum),
+		DefBallOE:      described.DefBallOddEven,
+		DefBallUnover:  described.DefBallUnderOver,
+		DefBallSize:    described.DefBallSize,
+		DefBallSection: described.DefBallSection,
+	}
+}
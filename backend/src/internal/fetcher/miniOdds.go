@@ -0,0 +1,32 @@
+package fetcher
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hotbrainy/go-betting/backend/db/initializers"
+	"github.com/hotbrainy/go-betting/backend/internal/services"
+)
+
+const miniOddsApplyInterval = 10 * time.Second
+
+// StartMiniOddsScheduler rolls the bet options forward to scheduled odds versions as they take
+// effect
+func StartMiniOddsScheduler() {
+	go func() {
+		fmt.Println("🚀 Starting mini-game odds version scheduler...")
+		ticker := time.NewTicker(miniOddsApplyInterval)
+		defer ticker.Stop()
+
+		for ; ; <-ticker.C {
+			applied, err := services.ApplyDueMiniOddsVersions(initializers.DB)
+			if err != nil {
+				fmt.Printf("❌ Failed to apply mini-game odds versions: %v\n", err)
+				continue
+			}
+			if applied > 0 {
+				fmt.Printf("✅ Applied %d mini-game odds versions\n", applied)
+			}
+		}
+	}()
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/hotbrainy/go-betting/backend/db/initializers"
 	"github.com/hotbrainy/go-betting/backend/internal/kafka"
+	"github.com/hotbrainy/go-betting/backend/internal/minigame"
 	"github.com/hotbrainy/go-betting/backend/internal/models"
 )
 
@@ -42,9 +43,29 @@ func StartPolling() {
 	honorLinkFetcher := NewHonorLinkFetcher()
 	honorLinkFetcher.StartPeriodicFetching()
 
-	// Start EOS Powerball fetcher
-	eosPowerballFetcher := NewEOS1MinPowerballFetcher()
-	eosPowerballFetcher.StartPeriodicFetching()
+	// Start casino game catalog sync
+	StartCasinoGameSync()
+
+	// Link casino bets recorded before rounds existed
+	StartCasinoRoundBackfill()
+
+	// Settle casino transfers left half-done
+	StartCasinoTransferRecovery()
+
+	// Start mini-game round clock
+	minigame.StartRoundClock()
+
+	// Start mini-game result scheduler for every EOS Powerball variant
+	if err := RegisterDefaultResultProviders(); err != nil {
+		log.Printf("❌ Failed to register mini-game result providers: %v", err)
+	}
+	StartResultScheduler()
+
+	// Start cancelling rounds that never get a result
+	StartRoundTimeoutSweeper()
+
+	// Start applying scheduled mini-game odds versions
+	StartMiniOddsScheduler()
 
 	// Start level update poller
 	StartLevelUpdatePoller()
@@ -0,0 +1,197 @@
+package fetcher
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hotbrainy/go-betting/backend/internal/minigame"
+)
+
+var (
+	// ErrRoundUnavailable is returned when a provider cannot serve the requested round yet
+	ErrRoundUnavailable = errors.New("round result is not available from this provider")
+	// ErrRoundLost is returned when a provider will never serve the requested round
+	ErrRoundLost = errors.New("round result is no longer available from this provider")
+)
+
+// MiniGameResultProvider supplies draw results for one mini-game type
+type MiniGameResultProvider interface {
+	// GameType returns the game type the provider serves (eos1min, ...)
+	GameType() string
+	// FetchLatest returns the most recently drawn result
+	FetchLatest(ctx context.Context) (*EOSPowerballResult, error)
+	// FetchRound returns the result of a specific round, ErrRoundUnavailable when it may still
+	// become available or ErrRoundLost when it never will
+	FetchRound(ctx context.Context, round minigame.Round) (*EOSPowerballResult, error)
+}
+
+var (
+	resultProviders   = map[string]MiniGameResultProvider{}
+	resultProvidersMu sync.RWMutex
+)
+
+// RegisterResultProvider registers p for its game type, replacing any previous provider
+func RegisterResultProvider(p MiniGameResultProvider) {
+	resultProvidersMu.Lock()
+	defer resultProvidersMu.Unlock()
+	resultProviders[p.GameType()] = p
+}
+
+// GetResultProvider returns the provider registered for gameType
+func GetResultProvider(gameType string) (MiniGameResultProvider, bool) {
+	resultProvidersMu.RLock()
+	defer resultProvidersMu.RUnlock()
+	p, ok := resultProviders[gameType]
+	return p, ok
+}
+
+// ResultProviders returns all registered providers ordered by game type
+func ResultProviders() []MiniGameResultProvider {
+	resultProvidersMu.RLock()
+	defer resultProvidersMu.RUnlock()
+
+	providers := make([]MiniGameResultProvider, 0, len(resultProviders))
+	for _, p := range resultProviders {
+		providers = append(providers, p)
+	}
+	sort.Slice(providers, func(i, j int) bool {
+		return providers[i].GameType() < providers[j].GameType()
+	})
+	return providers
+}
+
+// RegisterDefaultResultProviders registers the in-house games and the EOS Powerball feeds,
+// or the fixture file named by MINIGAME_RESULT_FIXTURES instead of the feeds when it is set
+func RegisterDefaultResultProviders() error {
+	// In-house games need no external feed
+	for gameType := range minigame.HouseGameTypes {
+		RegisterResultProvider(NewHouseResultProvider(gameType))
+	}
+
+	if path := os.Getenv("MINIGAME_RESULT_FIXTURES"); path != "" {
+		providers, err := LoadFixtureResultProviders(path)
+		if err != nil {
+			return err
+		}
+		for _, p := range providers {
+			RegisterResultProvider(p)
+		}
+		return nil
+	}
+
+	RegisterResultProvider(NewEOSResultProvider(NewEOS1MinPowerballFetcher()))
+	RegisterResultProvider(NewEOSResultProvider(NewEOS2MinPowerballFetcher()))
+	RegisterResultProvider(NewEOSResultProvider(NewEOS3MinPowerballFetcher()))
+	RegisterResultProvider(NewEOSResultProvider(NewEOS4MinPowerballFetcher()))
+	RegisterResultProvider(NewEOSResultProvider(NewEOS5MinPowerballFetcher()))
+	return nil
+}
+
+// EOSResultProvider adapts an EOSPowerballFetcher to MiniGameResultProvider
+type EOSResultProvider struct {
+	Fetcher *EOSPowerballFetcher
+}
+
+// NewEOSResultProvider wraps f as a result provider
+func NewEOSResultProvider(f *EOSPowerballFetcher) *EOSResultProvider {
+	return &EOSResultProvider{Fetcher: f}
+}
+
+// GameType implements MiniGameResultProvider
+func (p *EOSResultProvider) GameType() string {
+	return p.Fetcher.GameType
+}
+
+// FetchLatest implements MiniGameResultProvider
+func (p *EOSResultProvider) FetchLatest(ctx context.Context) (*EOSPowerballResult, error) {
+	return p.Fetcher.FetchResult()
+}
+
+// FetchRound implements MiniGameResultProvider. The public feed only exposes the latest
+// draw, so a round can only be served while it is still the latest; once the feed has moved
+// past it the round is lost.
+func (p *EOSResultProvider) FetchRound(ctx context.Context, round minigame.Round) (*EOSPowerballResult, error) {
+	result, err := p.Fetcher.FetchResult()
+	if err != nil {
+		return nil, err
+	}
+	if ParseDateRound(result.DateRound) == round.Round && (result.Date == "" || result.Date == round.Date) {
+		return result, nil
+	}
+
+	latest, err := minigame.RoundByNumber(p.GameType(), result.Date, ParseDateRound(result.DateRound))
+	if err == nil && latest.DrawAt.After(round.DrawAt) {
+		return nil, ErrRoundLost
+	}
+	return nil, ErrRoundUnavailable
+}
+
+// FixtureResultProvider serves results from a fixture file, for offline testing
+type FixtureResultProvider struct {
+	gameType string
+	results  map[uint]*EOSPowerballResult // By round number
+}
+
+// LoadFixtureResultProviders reads a JSON file of the form
+// {"eos1min": [<result.json object>, ...], "eos2min": [...]} and returns one provider per game type.
+// Fixtures are keyed by round number and replay every game day.
+func LoadFixtureResultProviders(path string) ([]*FixtureResultProvider, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixture file: %v", err)
+	}
+
+	var fixtures map[string][]EOSPowerballResult
+	if err := json.Unmarshal(data, &fixtures); err != nil {
+		return nil, fmt.Errorf("failed to parse fixture file: %v", err)
+	}
+
+	providers := make([]*FixtureResultProvider, 0, len(fixtures))
+	for gameType, results := range fixtures {
+		if _, ok := minigame.Schedules[gameType]; !ok {
+			return nil, fmt.Errorf("fixture file has unknown game type %q", gameType)
+		}
+
+		p := &FixtureResultProvider{
+			gameType: gameType,
+			results:  map[uint]*EOSPowerballResult{},
+		}
+		for i := range results {
+			p.results[ParseDateRound(results[i].DateRound)] = &results[i]
+		}
+		providers = append(providers, p)
+	}
+	return providers, nil
+}
+
+// GameType implements MiniGameResultProvider
+func (f *FixtureResultProvider) GameType() string {
+	return f.gameType
+}
+
+// FetchLatest implements MiniGameResultProvider
+func (f *FixtureResultProvider) FetchLatest(ctx context.Context) (*EOSPowerballResult, error) {
+	current, err := minigame.RoundAt(f.gameType, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return f.FetchRound(ctx, current.Previous())
+}
+
+// FetchRound implements MiniGameResultProvider
+func (f *FixtureResultProvider) FetchRound(ctx context.Context, round minigame.Round) (*EOSPowerballResult, error) {
+	result, ok := f.results[round.Round]
+	if !ok {
+		return nil, ErrRoundUnavailable
+	}
+
+	fixture := *result
+	fixture.Date = round.Date
+	return &fixture, nil
+}
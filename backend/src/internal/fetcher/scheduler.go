@@ -0,0 +1,228 @@
+package fetcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hotbrainy/go-betting/backend/db/initializers"
+	"github.com/hotbrainy/go-betting/backend/internal/minigame"
+	"github.com/hotbrainy/go-betting/backend/internal/redis"
+	"github.com/hotbrainy/go-betting/backend/internal/services"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+const (
+	resultFetchDelay = 3 * time.Second  // Give the provider a moment to publish after the draw
+	resultRetryBase  = 2 * time.Second  // First retry delay, doubled on every attempt
+	resultRetryMax   = 30 * time.Second // Longest delay between retries
+	gapRetention     = time.Hour        // Missed rounds older than this are given up on
+)
+
+func lastSettledKey(gameType string) string {
+	return "minigame:last:" + gameType
+}
+
+func gapsKey(gameType string) string {
+	return "minigame:gaps:" + gameType
+}
+
+// roundMember encodes a round as "<date>:<round>" for Redis
+func roundMember(r minigame.Round) string {
+	return fmt.Sprintf("%s:%d", r.Date, r.Round)
+}
+
+func parseRoundMember(gameType, member string) (minigame.Round, error) {
+	date, number, ok := strings.Cut(member, ":")
+	if !ok {
+		return minigame.Round{}, fmt.Errorf("invalid round member %q", member)
+	}
+	n, err := strconv.ParseUint(number, 10, 32)
+	if err != nil {
+		return minigame.Round{}, fmt.Errorf("invalid round member %q", member)
+	}
+	return minigame.RoundByNumber(gameType, date, uint(n))
+}
+
+// StartResultScheduler fetches and settles every registered mini-game on its own cadence.
+// Rounds that cannot be fetched before the next draw are recorded as gaps and backfilled later.
+func StartResultScheduler() {
+	for _, provider := range ResultProviders() {
+		go runResultSchedule(provider)
+	}
+}
+
+func runResultSchedule(p MiniGameResultProvider) {
+	gameType := p.GameType()
+	fmt.Printf("🚀 Starting %s result scheduler...\n", gameType)
+
+	current, err := minigame.RoundAt(gameType, time.Now())
+	if err != nil {
+		log.Printf("❌ Cannot schedule %s results: %v", gameType, err)
+		return
+	}
+
+	// Rounds drawn while no scheduler was running
+	detectMissedRounds(gameType, current)
+
+	for {
+		time.Sleep(time.Until(current.DrawAt.Add(resultFetchDelay)))
+
+		settleDrawnRound(p, current)
+		backfillGaps(p)
+
+		current = current.Next()
+	}
+}
+
+// settleDrawnRound polls the latest result until it is the drawn round, backing off between
+// attempts. If the next draw arrives first the round is recorded as a gap.
+func settleDrawnRound(p MiniGameResultProvider, round minigame.Round) {
+	ctx := context.Background()
+	deadline := round.Next().DrawAt
+	delay := resultRetryBase
+
+	for {
+		result, err := p.FetchLatest(ctx)
+		if err == nil && ParseDateRound(result.DateRound) == round.Round {
+			SettleRound(round, result)
+			markRoundSettled(round)
+			return
+		}
+		if err != nil {
+			log.Printf("⚠️ Failed to fetch %s round %d: %v", round.GameType, round.Round, err)
+		}
+
+		if time.Now().Add(delay).After(deadline) {
+			log.Printf("⚠️ %s round %d not available before the next draw, will backfill", round.GameType, round.Round)
+			addRoundGap(round)
+			return
+		}
+
+		time.Sleep(delay)
+		delay *= 2
+		if delay > resultRetryMax {
+			delay = resultRetryMax
+		}
+	}
+}
+
+// detectMissedRounds records every round between the last settled one and current as a gap
+func detectMissedRounds(gameType string, current minigame.Round) {
+	if redis.Client == nil {
+		return
+	}
+
+	member, err := redis.Client.Get(context.Background(), lastSettledKey(gameType)).Result()
+	if err != nil {
+		return
+	}
+	last, err := parseRoundMember(gameType, member)
+	if err != nil {
+		return
+	}
+
+	cutoff := time.Now().Add(-gapRetention)
+	for r := last.Next(); r.DrawAt.Before(current.DrawAt); r = r.Next() {
+		if r.DrawAt.After(cutoff) {
+			addRoundGap(r)
+		}
+	}
+}
+
+// backfillGaps retries every recorded gap of the provider's game type
+func backfillGaps(p MiniGameResultProvider) {
+	if redis.Client == nil {
+		return
+	}
+
+	ctx := context.Background()
+	gameType := p.GameType()
+
+	members, err := redis.Client.ZRange(ctx, gapsKey(gameType), 0, -1).Result()
+	if err != nil {
+		log.Printf("⚠️ Failed to load %s gaps: %v", gameType, err)
+		return
+	}
+
+	cutoff := time.Now().Add(-gapRetention)
+	for _, member := range members {
+		round, err := parseRoundMember(gameType, member)
+		if err != nil {
+			redis.Client.ZRem(ctx, gapsKey(gameType), member)
+			continue
+		}
+
+		if round.DrawAt.Before(cutoff) {
+			abandonRoundGap(round, "was not backfilled in time")
+			redis.Client.ZRem(ctx, gapsKey(gameType), member)
+			continue
+		}
+
+		result, err := p.FetchRound(ctx, round)
+		if errors.Is(err, ErrRoundLost) {
+			abandonRoundGap(round, "is no longer served by the result feed")
+			redis.Client.ZRem(ctx, gapsKey(gameType), member)
+			continue
+		}
+		if err != nil {
+			if !errors.Is(err, ErrRoundUnavailable) {
+				log.Printf("⚠️ Failed to backfill %s round %d: %v", gameType, round.Round, err)
+			}
+			continue
+		}
+
+		fmt.Printf("🔁 Backfilling %s round %d of %s\n", gameType, round.Round, round.Date)
+		SettleRound(round, result)
+		redis.Client.ZRem(ctx, gapsKey(gameType), member)
+		markRoundSettled(round)
+	}
+}
+
+// abandonRoundGap reports a round that will not be backfilled. Its pending bets are refunded by
+// the timeout sweeper unless an admin enters the round result first.
+func abandonRoundGap(r minigame.Round, reason string) {
+	outcome := "its pending bets need manual settlement"
+	if config := services.GetMiniGameSettlementConfig(initializers.DB); config.CancelAfterMinutes > 0 {
+		outcome = fmt.Sprintf("its pending bets will be cancelled and refunded %d minutes after the draw unless an admin enters the result first",
+			config.CancelAfterMinutes)
+	}
+	log.Printf("❌ Giving up on %s round %d of %s, it %s; %s", r.GameType, r.Round, r.Date, reason, outcome)
+
+	title := "Mini Game Round Lost"
+	message := fmt.Sprintf("The result of %s round %d of %s %s, so %s.",
+		r.GameType, r.Round, r.Date, reason, outcome)
+	if _, err := services.CreateAlert(initializers.DB, "miniRoundLost", title, message, r.Round, "/admin/mini/rounds"); err != nil {
+		fmt.Printf("Error creating mini round alert: %v\n", err)
+	}
+}
+
+func addRoundGap(r minigame.Round) {
+	if redis.Client == nil {
+		return
+	}
+	redis.Client.ZAdd(context.Background(), gapsKey(r.GameType), goredis.Z{
+		Score:  float64(r.DrawAt.Unix()),
+		Member: roundMember(r),
+	})
+}
+
+// markRoundSettled moves the last settled round forward; backfilled rounds never move it back
+func markRoundSettled(r minigame.Round) {
+	if redis.Client == nil {
+		return
+	}
+
+	ctx := context.Background()
+	key := lastSettledKey(r.GameType)
+	if member, err := redis.Client.Get(ctx, key).Result(); err == nil {
+		if last, err := parseRoundMember(r.GameType, member); err == nil && !last.DrawAt.Before(r.DrawAt) {
+			return
+		}
+	}
+	redis.Client.Set(ctx, key, roundMember(r), 0)
+}
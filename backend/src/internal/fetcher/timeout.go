@@ -0,0 +1,85 @@
+package fetcher
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hotbrainy/go-betting/backend/db/initializers"
+	"github.com/hotbrainy/go-betting/backend/internal/minigame"
+	"github.com/hotbrainy/go-betting/backend/internal/models"
+	"github.com/hotbrainy/go-betting/backend/internal/services"
+)
+
+const roundTimeoutInterval = time.Minute
+
+// StartRoundTimeoutSweeper cancels and refunds rounds that are still without a result
+// the configured time after their draw
+func StartRoundTimeoutSweeper() {
+	go func() {
+		fmt.Println("🚀 Starting mini-game round timeout sweeper...")
+		ticker := time.NewTicker(roundTimeoutInterval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			cancelStaleRounds(time.Now())
+		}
+	}()
+}
+
+// staleRound is the pending bets stored under one round number of a game type and game day
+type staleRound struct {
+	gameType string
+	date     string
+	round    uint
+	bets     []models.PowerballHistory
+}
+
+func cancelStaleRounds(now time.Time) {
+	config := services.GetMiniGameSettlementConfig(initializers.DB)
+	if config.CancelAfterMinutes <= 0 {
+		return
+	}
+	delay := time.Duration(config.CancelAfterMinutes) * time.Minute
+
+	// Any bet placed before now-delay belongs to a round drawn before then
+	var bets []models.PowerballHistory
+	if err := initializers.DB.Where("status = ? AND created_at < ?", "pending", now.Add(-delay)).
+		Find(&bets).Error; err != nil {
+		log.Printf("❌ Failed to load stale mini-game bets: %v", err)
+		return
+	}
+
+	// Bets are grouped by the round they are stored under, which legacy bets do not share with
+	// the round running when they were placed
+	stale := map[string]*staleRound{}
+	for _, bet := range bets {
+		placed, err := minigame.RoundAt(bet.GameType, bet.CreatedAt)
+		if err != nil || now.Before(placed.DrawAt.Add(delay)) {
+			continue
+		}
+		key := fmt.Sprintf("%s:%s:%d", bet.GameType, placed.Date, bet.Round)
+		if stale[key] == nil {
+			stale[key] = &staleRound{gameType: bet.GameType, date: placed.Date, round: bet.Round}
+		}
+		stale[key].bets = append(stale[key].bets, bet)
+	}
+
+	reason := fmt.Sprintf("No result within %d minutes of the draw", config.CancelAfterMinutes)
+	for _, round := range stale {
+		tx := initializers.DB.Begin()
+		action, err := services.CancelMiniRoundBets(tx, round.gameType, round.date, round.round, round.bets, "timeout_cancel", reason, nil)
+		if err != nil {
+			tx.Rollback()
+			log.Printf("❌ Failed to cancel %s round %d: %v", round.gameType, round.round, err)
+			continue
+		}
+		if err := tx.Commit().Error; err != nil {
+			log.Printf("❌ Failed to cancel %s round %d: %v", round.gameType, round.round, err)
+			continue
+		}
+		if action.Bets > 0 {
+			log.Printf("⏱️ %s round %d timed out, %d bets refunded", round.gameType, round.round, action.Bets)
+		}
+	}
+}
@@ -41,7 +41,14 @@ func GetGinAuthUser(c *gin.Context) (*models.User, error) {
 	if !exists {
 		return nil, fmt.Errorf("Failed to get the user")
 	}
-	return user.(*models.User), nil
+	// Admin and partner middlewares store the user by value
+	switch u := user.(type) {
+	case *models.User:
+		return u, nil
+	case models.User:
+		return &u, nil
+	}
+	return nil, fmt.Errorf("Failed to get the user")
 }
 
 // GetGinAccessDomain returns the domain from the Gin context
@@ -9,6 +9,7 @@ import (
 	"github.com/hotbrainy/go-betting/backend/graph/model"
 	"github.com/hotbrainy/go-betting/backend/internal/helpers"
 	"github.com/hotbrainy/go-betting/backend/internal/models"
+	"github.com/hotbrainy/go-betting/backend/internal/services"
 	"gorm.io/gorm"
 )
 
@@ -275,6 +276,10 @@ func (pr *transactionReader) ApproveTransaction(ctx context.Context, id uint) (b
 			return false, err
 		}
 
+		if err := services.GrantDepositRouletteSpins(initializers.DB, tr.UserID, tr.ID, tr.Amount); err != nil {
+			return false, err
+		}
+
 	} else if tr.Type == "withdrawal" {
 		if profile.Balance < tr.Amount {
 			return false, fmt.Errorf("insufficient balance")
@@ -3,7 +3,6 @@ package loaders
 import (
 	"context"
 	"fmt"
-	"strings"
 	"time"
 
 	"github.com/hotbrainy/go-betting/backend/db/initializers"
@@ -551,49 +550,9 @@ func (ur *userReader) GetDistributorDetails(ctx context.Context, filters []*mode
 		user.LosingRate = user.EntireLosing
 		user.LosingSettlement = user.LiveLosingBeDang + user.SlotLosingBeDang + user.HoldLosingBeDang
 
-		// Calculate betting/winning statistics from transactions and bets
-		// Get casino bets (live and slot) from CasinoBet table
-		var casinoBets []models.CasinoBet
-		if err := ur.db.Model(&models.CasinoBet{}).
-			Where("user_id = ?", user.ID).
-			Find(&casinoBets).Error; err == nil {
-			for _, bet := range casinoBets {
-				gameNameLower := strings.ToLower(bet.GameName)
-				if bet.Type == "bet" {
-					if strings.Contains(gameNameLower, "live") {
-						user.LiveBetting += bet.Amount
-					} else if strings.Contains(gameNameLower, "slot") {
-						user.SlotBetting += bet.Amount
-					}
-				} else if bet.Type == "win" {
-					if strings.Contains(gameNameLower, "live") {
-						user.LiveWinning += float64(bet.WinningAmount)
-					} else if strings.Contains(gameNameLower, "slot") {
-						user.SlotJackpot += float64(bet.WinningAmount)
-					}
-				}
-			}
-		}
-
-		// Get mini game bets from PowerballHistory
-		var miniBets []models.PowerballHistory
-		if err := ur.db.Model(&models.PowerballHistory{}).
-			Where("user_id = ?", user.ID).
-			Find(&miniBets).Error; err == nil {
-			for _, bet := range miniBets {
-				if bet.BetType == "single" {
-					user.MiniDanpolBetting += bet.Amount
-					if bet.Result == "won" {
-						user.MiniDanpolWinner += bet.Amount * bet.Odds
-					}
-				} else if bet.BetType == "combination" {
-					user.MiniCombinationBetting += bet.Amount
-					if bet.Result == "won" {
-						user.MiniCombinationWinnings += bet.Amount * bet.Odds
-					}
-				}
-			}
-		}
+		// Calculate betting/winning statistics from transactions and bets. Casino live/slot/holdem
+		// and mini game single/combination statistics are maintained on the user as bets are
+		// ingested and settled.
 
 		// Get sports bets from Bet table
 		var sportsBets []models.Bet
@@ -651,7 +610,7 @@ func (ur *userReader) GetDistributorDetails(ctx context.Context, filters []*mode
 
 		// Calculate partnership statistics
 		user.PartnershipRolling = user.RollingHoldings
-		if user.Profile != nil {
+		if user.Profile.ID != 0 {
 			user.PartnershipMoneyInHand = user.Profile.Balance
 		}
 	}
@@ -0,0 +1,155 @@
+package minigame
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	"github.com/hotbrainy/go-betting/backend/internal/models"
+)
+
+// HouseGameTypes are the powerball variants drawn by our own server instead of an external feed
+var HouseGameTypes = map[string]bool{
+	"house3min": true,
+}
+
+// HouseDraw is the result of one in-house powerball round.
+// Like EOS Powerball it draws 5 distinct normal balls from 1-28 and a powerball from 0-9.
+type HouseDraw struct {
+	Balls     [5]int `json:"balls"` // In draw order
+	PowerBall int    `json:"powerBall"`
+}
+
+// Sum returns the sum of the normal balls
+func (d HouseDraw) Sum() int {
+	sum := 0
+	for _, ball := range d.Balls {
+		sum += ball
+	}
+	return sum
+}
+
+// MiniGameDraw describes d as the draw of round, with outcomes following the EOS Powerball rules
+func (d HouseDraw) MiniGameDraw(round Round) models.MiniGameDraw {
+	oddEven := func(n int) string {
+		if n%2 != 0 {
+			return "홀"
+		}
+		return "짝"
+	}
+
+	sum := d.Sum()
+
+	// Powerball 0-4 is under, normal ball sum up to 72 is under
+	powUnover := "오버"
+	if d.PowerBall <= 4 {
+		powUnover = "언더"
+	}
+	defUnover := "오버"
+	if sum <= 72 {
+		defUnover = "언더"
+	}
+
+	// Small 15-64, medium 65-80, large 81-130
+	size := "중"
+	switch {
+	case sum <= 64:
+		size = "작"
+	case sum >= 81:
+		size = "큰"
+	}
+
+	// A 15-35, B 36-49, C 50-57, D 58-65, E 66-130
+	section := "E"
+	switch {
+	case sum <= 35:
+		section = "A"
+	case sum <= 49:
+		section = "B"
+	case sum <= 57:
+		section = "C"
+	case sum <= 65:
+		section = "D"
+	}
+
+	return models.MiniGameDraw{
+		GameType:         round.GameType,
+		DrawDate:         round.Date,
+		Round:            round.Round,
+		DrawnAt:          round.DrawAt,
+		Ball1:            d.Balls[0],
+		Ball2:            d.Balls[1],
+		Ball3:            d.Balls[2],
+		Ball4:            d.Balls[3],
+		Ball5:            d.Balls[4],
+		PowerBall:        d.PowerBall,
+		PowBallOddEven:   oddEven(d.PowerBall),
+		PowBallUnderOver: powUnover,
+		DefBallSum:       sum,
+		DefBallOddEven:   oddEven(sum),
+		DefBallUnderOver: defUnover,
+		DefBallSize:      size,
+		DefBallSection:   section,
+	}
+}
+
+// HouseDrawMessage is the message a round's draw is derived from: "<gameType>:<date>:<round>"
+func HouseDrawMessage(gameType, date string, round uint) string {
+	return fmt.Sprintf("%s:%s:%d", gameType, date, round)
+}
+
+// NewHouseDraw derives the draw of a round from its server seed. Random numbers are read from
+// the stream HMAC-SHA256(serverSeed, "<message>:<counter>") for counter = 0, 1, ..., 4 bytes at a
+// time as big-endian uint32s. A number below n is taken as v % n, rejecting v >= 2^32 - 2^32 % n.
+// Normal balls are a partial Fisher-Yates shuffle of 1..28; the powerball is the next number below 10.
+func NewHouseDraw(serverSeed, gameType, date string, round uint) HouseDraw {
+	stream := &houseStream{
+		key:     []byte(serverSeed),
+		message: HouseDrawMessage(gameType, date, round),
+	}
+
+	var pool [28]int
+	for i := range pool {
+		pool[i] = i + 1
+	}
+
+	var draw HouseDraw
+	for i := range draw.Balls {
+		j := i + stream.intn(len(pool)-i)
+		pool[i], pool[j] = pool[j], pool[i]
+		draw.Balls[i] = pool[i]
+	}
+	draw.PowerBall = stream.intn(10)
+
+	return draw
+}
+
+type houseStream struct {
+	key     []byte
+	message string
+	counter uint32
+	buf     []byte
+}
+
+func (s *houseStream) uint32() uint32 {
+	if len(s.buf) < 4 {
+		mac := hmac.New(sha256.New, s.key)
+		mac.Write([]byte(fmt.Sprintf("%s:%d", s.message, s.counter)))
+		s.buf = mac.Sum(nil)
+		s.counter++
+	}
+	v := binary.BigEndian.Uint32(s.buf[:4])
+	s.buf = s.buf[4:]
+	return v
+}
+
+func (s *houseStream) intn(n int) int {
+	limit := math.MaxUint32 - (math.MaxUint32%uint32(n)+1)%uint32(n)
+	for {
+		if v := s.uint32(); v <= limit {
+			return int(v % uint32(n))
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package minigame
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestNewHouseDraw(t *testing.T) {
+	first := NewHouseDraw("seed", "house3min", "2026-01-01", 1)
+	if again := NewHouseDraw("seed", "house3min", "2026-01-01", 1); again != first {
+		t.Fatalf("draw is not deterministic: %v != %v", first, again)
+	}
+
+	for round := uint(1); round <= 480; round++ {
+		draw := NewHouseDraw(fmt.Sprintf("seed-%d", round), "house3min", "2026-01-01", round)
+
+		seen := map[int]bool{}
+		for _, ball := range draw.Balls {
+			if ball < 1 || ball > 28 {
+				t.Fatalf("round %d: normal ball %d out of range", round, ball)
+			}
+			if seen[ball] {
+				t.Fatalf("round %d: normal ball %d drawn twice", round, ball)
+			}
+			seen[ball] = true
+		}
+		if draw.PowerBall < 0 || draw.PowerBall > 9 {
+			t.Fatalf("round %d: powerball %d out of range", round, draw.PowerBall)
+		}
+	}
+}
@@ -0,0 +1,194 @@
+package minigame
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hotbrainy/go-betting/backend/internal/redis"
+)
+
+// RoundSchedule describes the cadence of one mini-game type
+type RoundSchedule struct {
+	GameType string
+	Interval time.Duration // Time between draws
+	Cutoff   time.Duration // Betting closes this long before the draw
+}
+
+// Schedules holds the round cadence of every supported game type.
+// EOS Powerball numbers its rounds from 1 at midnight KST and draws at the end of each interval.
+var Schedules = map[string]RoundSchedule{
+	"eos1min": {GameType: "eos1min", Interval: 1 * time.Minute, Cutoff: 10 * time.Second},
+	"eos2min": {GameType: "eos2min", Interval: 2 * time.Minute, Cutoff: 15 * time.Second},
+	"eos3min": {GameType: "eos3min", Interval: 3 * time.Minute, Cutoff: 20 * time.Second},
+	"eos4min": {GameType: "eos4min", Interval: 4 * time.Minute, Cutoff: 25 * time.Second},
+	"eos5min": {GameType: "eos5min", Interval: 5 * time.Minute, Cutoff: 30 * time.Second},
+	// In-house powerball, see HouseGameTypes
+	"house3min": {GameType: "house3min", Interval: 3 * time.Minute, Cutoff: 20 * time.Second},
+}
+
+// RoundChannel is the Redis channel round changes are published on
+const RoundChannel = "minigame:rounds"
+
+// Location is the timezone the game day starts in
+var Location = loadLocation()
+
+func loadLocation() *time.Location {
+	loc, err := time.LoadLocation("Asia/Seoul")
+	if err != nil {
+		return time.FixedZone("KST", 9*60*60)
+	}
+	return loc
+}
+
+// Round is one draw of a game type
+type Round struct {
+	GameType string    `json:"gameType"`
+	Date     string    `json:"date"`  // Game day (YYYY-MM-DD, KST)
+	Round    uint      `json:"round"` // Round number within the game day, from 1
+	OpenAt   time.Time `json:"openAt"`
+	CloseAt  time.Time `json:"closeAt"`
+	DrawAt   time.Time `json:"drawAt"`
+}
+
+// IsOpen reports whether bets for the round are accepted at t
+func (r Round) IsOpen(t time.Time) bool {
+	return !t.Before(r.OpenAt) && t.Before(r.CloseAt)
+}
+
+// Status returns "open" or "closed" at t
+func (r Round) Status(t time.Time) string {
+	if r.IsOpen(t) {
+		return "open"
+	}
+	return "closed"
+}
+
+// RoundAt returns the round of gameType that is running at t
+func RoundAt(gameType string, t time.Time) (Round, error) {
+	schedule, ok := Schedules[gameType]
+	if !ok {
+		return Round{}, fmt.Errorf("unknown game type %q", gameType)
+	}
+
+	local := t.In(Location)
+	midnight := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, Location)
+	index := local.Sub(midnight) / schedule.Interval
+
+	openAt := midnight.Add(index * schedule.Interval)
+	drawAt := openAt.Add(schedule.Interval)
+
+	return Round{
+		GameType: gameType,
+		Date:     midnight.Format("2006-01-02"),
+		Round:    uint(index) + 1,
+		OpenAt:   openAt,
+		CloseAt:  drawAt.Add(-schedule.Cutoff),
+		DrawAt:   drawAt,
+	}, nil
+}
+
+// RoundByNumber returns round number of gameType on the game day date (YYYY-MM-DD)
+func RoundByNumber(gameType, date string, number uint) (Round, error) {
+	schedule, ok := Schedules[gameType]
+	if !ok {
+		return Round{}, fmt.Errorf("unknown game type %q", gameType)
+	}
+	if number == 0 {
+		return Round{}, fmt.Errorf("invalid round number 0")
+	}
+
+	midnight, err := time.ParseInLocation("2006-01-02", date, Location)
+	if err != nil {
+		return Round{}, fmt.Errorf("invalid game day %q: %v", date, err)
+	}
+
+	return RoundAt(gameType, midnight.Add(time.Duration(number-1)*schedule.Interval))
+}
+
+// Previous returns the round drawn just before r
+func (r Round) Previous() Round {
+	previous, _ := RoundAt(r.GameType, r.OpenAt.Add(-time.Second))
+	return previous
+}
+
+// Next returns the round drawn just after r
+func (r Round) Next() Round {
+	next, _ := RoundAt(r.GameType, r.DrawAt)
+	return next
+}
+
+func roundKey(gameType string) string {
+	return "minigame:round:" + gameType
+}
+
+// CurrentRound returns the current round of gameType as published in Redis.
+// If the clock has not published it yet, the round is computed locally.
+func CurrentRound(ctx context.Context, gameType string) (Round, error) {
+	now := time.Now()
+	if _, ok := Schedules[gameType]; !ok {
+		return Round{}, fmt.Errorf("unknown game type %q", gameType)
+	}
+
+	if redis.Client != nil {
+		if data, err := redis.Client.Get(ctx, roundKey(gameType)).Bytes(); err == nil {
+			var round Round
+			// Ignore a stale entry left behind by a stopped clock
+			if json.Unmarshal(data, &round) == nil && now.Before(round.DrawAt) {
+				return round, nil
+			}
+		}
+	}
+
+	return RoundAt(gameType, now)
+}
+
+// StartRoundClock publishes the current round of every game type to Redis once per second.
+// Every replica runs the clock; since rounds are derived from wall time they all write the same state.
+func StartRoundClock() {
+	ticker := time.NewTicker(time.Second)
+
+	go func() {
+		fmt.Println("🚀 Starting mini-game round clock...")
+
+		published := map[string]string{}
+		for now := range ticker.C {
+			for gameType, schedule := range Schedules {
+				round, _ := RoundAt(gameType, now)
+
+				state := fmt.Sprintf("%s:%d:%s", round.Date, round.Round, round.Status(now))
+				if published[gameType] == state {
+					continue
+				}
+
+				if err := publishRound(round, schedule, now); err != nil {
+					log.Printf("⚠️ Failed to publish %s round %d: %v", gameType, round.Round, err)
+					continue
+				}
+				published[gameType] = state
+			}
+		}
+	}()
+}
+
+func publishRound(round Round, schedule RoundSchedule, now time.Time) error {
+	ctx := context.Background()
+
+	data, err := json.Marshal(round)
+	if err != nil {
+		return err
+	}
+
+	if err := redis.Client.Set(ctx, roundKey(round.GameType), data, schedule.Interval*2).Err(); err != nil {
+		return err
+	}
+
+	message, _ := json.Marshal(map[string]interface{}{
+		"type":   "round",
+		"status": round.Status(now),
+		"round":  round,
+	})
+	return redis.Client.Publish(ctx, RoundChannel, message).Err()
+}
@@ -0,0 +1,167 @@
+package minigame
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hotbrainy/go-betting/backend/internal/models"
+)
+
+// ErrUnknownPick is returned when a bet names a pick the rule table does not know
+var ErrUnknownPick = errors.New("unknown mini-game pick")
+
+// Ball kinds a pick can refer to
+const (
+	PowerballKind  = "powerball"
+	NormalballKind = "normalball"
+)
+
+// Outcome is a draw normalized for grading, independent of the provider's result strings
+type Outcome struct {
+	PowerballOdd  bool
+	PowerballOver bool
+	NormalOdd     bool
+	NormalOver    bool
+	NormalSize    string // "small", "medium" or "large"
+	NormalSection string // "a" to "e"
+	NormalSum     int
+}
+
+// NewOutcome normalizes a stored draw. The EOS feed reports outcomes in Korean
+// (홀/짝, 오버/언더, 소/중/대); English values are accepted as well.
+func NewOutcome(draw models.MiniGameDraw) Outcome {
+	return Outcome{
+		PowerballOdd:  normalizePick(draw.PowBallOddEven) == "odd",
+		PowerballOver: normalizePick(draw.PowBallUnderOver) == "over",
+		NormalOdd:     normalizePick(draw.DefBallOddEven) == "odd",
+		NormalOver:    normalizePick(draw.DefBallUnderOver) == "over",
+		NormalSize:    normalizePick(draw.DefBallSize),
+		NormalSection: normalizePick(draw.DefBallSection),
+		NormalSum:     draw.DefBallSum,
+	}
+}
+
+// pickAliases maps every spelling of a pick to its normalized form
+var pickAliases = map[string]string{
+	"odd": "odd", "홀": "odd",
+	"even": "even", "짝": "even",
+	"under": "under", "언더": "under",
+	"over": "over", "오버": "over",
+	"small": "small", "소": "small", "작": "small",
+	"medium": "medium", "중": "medium",
+	"large": "large", "대": "large", "큰": "large",
+}
+
+func normalizePick(pick string) string {
+	pick = strings.ToLower(strings.TrimSpace(pick))
+	if alias, ok := pickAliases[pick]; ok {
+		return alias
+	}
+	return pick
+}
+
+// PickRule reports whether a pick won against an outcome
+type PickRule func(o Outcome) bool
+
+// pickRules is the rule table: for each ball kind, the predicate of every normalized pick.
+// Numeric normalball picks are matched against the sum separately.
+var pickRules = map[string]map[string]PickRule{
+	PowerballKind: {
+		"odd":   func(o Outcome) bool { return o.PowerballOdd },
+		"even":  func(o Outcome) bool { return !o.PowerballOdd },
+		"under": func(o Outcome) bool { return !o.PowerballOver },
+		"over":  func(o Outcome) bool { return o.PowerballOver },
+	},
+	NormalballKind: {
+		"odd":    func(o Outcome) bool { return o.NormalOdd },
+		"even":   func(o Outcome) bool { return !o.NormalOdd },
+		"under":  func(o Outcome) bool { return !o.NormalOver },
+		"over":   func(o Outcome) bool { return o.NormalOver },
+		"small":  func(o Outcome) bool { return o.NormalSize == "small" },
+		"medium": func(o Outcome) bool { return o.NormalSize == "medium" },
+		"large":  func(o Outcome) bool { return o.NormalSize == "large" },
+		"a":      func(o Outcome) bool { return o.NormalSection == "a" },
+		"b":      func(o Outcome) bool { return o.NormalSection == "b" },
+		"c":      func(o Outcome) bool { return o.NormalSection == "c" },
+		"d":      func(o Outcome) bool { return o.NormalSection == "d" },
+		"e":      func(o Outcome) bool { return o.NormalSection == "e" },
+	},
+}
+
+// categoryBalls lists, for each bet category, which ball kind each pick of a bet refers to.
+// Single bets use the first entry; combination picks are matched by position, and picks
+// beyond the end of the list use its last entry.
+var categoryBalls = map[string][]string{
+	"powerball":         {PowerballKind},
+	"normalball":        {NormalballKind},
+	"normalballsection": {NormalballKind},
+	"oddeven":           {PowerballKind, NormalballKind},                 // Powerball + normalball odd/even
+	"threecombination":  {NormalballKind, NormalballKind, PowerballKind}, // Normalball pair + powerball
+}
+
+// PickRuleFor returns the rule of the index-th pick of a bet in category
+func PickRuleFor(category string, index int, pick string) (PickRule, error) {
+	balls, ok := categoryBalls[category]
+	if !ok {
+		return nil, fmt.Errorf("%w: category %q", ErrUnknownPick, category)
+	}
+	if index >= len(balls) {
+		index = len(balls) - 1
+	}
+	kind := balls[index]
+
+	normalized := normalizePick(pick)
+	if rule, ok := pickRules[kind][normalized]; ok {
+		return rule, nil
+	}
+	if kind == NormalballKind {
+		if sum, err := strconv.Atoi(normalized); err == nil {
+			return func(o Outcome) bool { return o.NormalSum == sum }, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %q for %s", ErrUnknownPick, pick, kind)
+}
+
+// BetPicks returns the picks of a bet: the option text for single bets, every ball for
+// combination bets, and the raw pick selection for bets placed without an option
+func BetPicks(bet models.PowerballHistory) []string {
+	switch {
+	case bet.BetType == "single" && bet.BetText != nil:
+		return []string{*bet.BetText}
+	case bet.BetType == "combination" && len(bet.BetBalls) > 0:
+		picks := make([]string, 0, len(bet.BetBalls))
+		for _, ball := range bet.BetBalls {
+			picks = append(picks, ball.Text)
+		}
+		return picks
+	default:
+		return []string{bet.PickSelection}
+	}
+}
+
+// PickKey identifies what a bet is on regardless of the option or level it was placed
+// through, e.g. "powerball:odd" or "oddeven:odd+even". Exposure is tracked per pick key.
+func PickKey(bet models.PowerballHistory) string {
+	picks := BetPicks(bet)
+	for i, pick := range picks {
+		picks[i] = normalizePick(pick)
+	}
+	return bet.Category + ":" + strings.Join(picks, "+")
+}
+
+// GradeBet reports whether bet won against outcome; every pick of a combination must win
+func GradeBet(bet models.PowerballHistory, outcome Outcome) (bool, error) {
+	won := true
+	for i, pick := range BetPicks(bet) {
+		rule, err := PickRuleFor(bet.Category, i, pick)
+		if err != nil {
+			return false, err
+		}
+		if !rule(outcome) {
+			won = false
+		}
+	}
+	return won, nil
+}
@@ -0,0 +1,176 @@
+package minigame
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hotbrainy/go-betting/backend/internal/models"
+)
+
+// Draws as the EOS feed reports them
+var (
+	// Powerball 7 (odd, over), normal balls sum 65 (odd, under, medium, section C)
+	drawA = models.MiniGameDraw{
+		PowerBall: 7, PowBallOddEven: "홀", PowBallUnderOver: "오버",
+		DefBallSum: 65, DefBallOddEven: "홀", DefBallUnderOver: "언더", DefBallSize: "중", DefBallSection: "C",
+	}
+	// Powerball 2 (even, under), normal balls sum 90 (even, over, large, section E)
+	drawB = models.MiniGameDraw{
+		PowerBall: 2, PowBallOddEven: "짝", PowBallUnderOver: "언더",
+		DefBallSum: 90, DefBallOddEven: "짝", DefBallUnderOver: "오버", DefBallSize: "대", DefBallSection: "E",
+	}
+)
+
+func betFromOption(option models.MiniBetOption) models.PowerballHistory {
+	return models.PowerballHistory{
+		Category: option.Category,
+		BetType:  option.Type,
+		BetBall:  option.Ball,
+		BetText:  option.Text,
+		BetBalls: option.Balls,
+	}
+}
+
+func TestGradeBetDefaultOptions(t *testing.T) {
+	// Expected result against drawA and drawB for every default option
+	tests := map[string][2]bool{
+		"Powerball Odd":    {true, false},
+		"Powerball Even":   {false, true},
+		"Powerball Under":  {false, true},
+		"Powerball Over":   {true, false},
+		"PaOdd-PaUnder":    {false, false},
+		"PaOdd-PaOver":     {true, false},
+		"PaEven-PaUnder":   {false, true},
+		"PaEven-PaOver":    {false, false},
+		"Normalball Odd":   {true, false},
+		"Normalball Even":  {false, true},
+		"Normalball Under": {true, false},
+		"Normalball Over":  {false, true},
+		"N-NUnder":         {true, false},
+		"N-NOver":          {false, false},
+		"NOdd-NUnder":      {false, false},
+		"NEven-NOver":      {false, true},
+	}
+
+	options := models.DefaultMiniBetOptions("eos1min", 1)
+	if len(options) != len(tests) {
+		t.Fatalf("default options = %d, test table covers %d", len(options), len(tests))
+	}
+
+	for _, option := range options {
+		want, ok := tests[option.Name]
+		if !ok {
+			t.Errorf("default option %q has no test case", option.Name)
+			continue
+		}
+
+		for i, draw := range []models.MiniGameDraw{drawA, drawB} {
+			got, err := GradeBet(betFromOption(option), NewOutcome(draw))
+			if err != nil {
+				t.Errorf("%s draw %d: unexpected error %v", option.Name, i, err)
+				continue
+			}
+			if got != want[i] {
+				t.Errorf("%s draw %d: won = %v, want %v", option.Name, i, got, want[i])
+			}
+		}
+	}
+}
+
+func TestGradeBet(t *testing.T) {
+	single := func(category, text string) models.PowerballHistory {
+		return models.PowerballHistory{Category: category, BetType: "single", BetText: &text}
+	}
+	combination := func(category string, texts ...string) models.PowerballHistory {
+		balls := make([]models.BallOption, 0, len(texts))
+		for _, text := range texts {
+			balls = append(balls, models.BallOption{Text: text})
+		}
+		return models.PowerballHistory{Category: category, BetType: "combination", BetBalls: balls}
+	}
+
+	tests := []struct {
+		name string
+		bet  models.PowerballHistory
+		draw models.MiniGameDraw
+		want bool
+	}{
+		{"korean pick", single("powerball", "홀"), drawA, true},
+		{"lowercase pick", single("normalball", "under"), drawA, true},
+		{"size medium", single("normalball", "Medium"), drawA, true},
+		{"size large", single("normalball", "Large"), drawA, false},
+		{"size korean", single("normalball", "대"), drawB, true},
+		{"section", single("normalballsection", "C"), drawA, true},
+		{"section miss", single("normalballsection", "A"), drawA, false},
+		{"section lowercase", single("normalballsection", "e"), drawB, true},
+		{"sum", single("normalball", "65"), drawA, true},
+		{"sum miss", single("normalball", "66"), drawA, false},
+		{"oddeven powerball odd normal odd", combination("oddeven", "Odd", "Odd"), drawA, true},
+		{"oddeven powerball odd normal even", combination("oddeven", "Odd", "Even"), drawA, false},
+		{"oddeven powerball even normal even", combination("oddeven", "Even", "Even"), drawB, true},
+		{"threecombination win", combination("threecombination", "Odd", "Under", "Odd"), drawA, true},
+		{"threecombination powerball miss", combination("threecombination", "Odd", "Under", "Even"), drawA, false},
+		{"threecombination normal miss", combination("threecombination", "Even", "Over", "Odd"), drawA, false},
+		{"legacy pick selection", models.PowerballHistory{Category: "powerball", PickSelection: "Even"}, drawB, true},
+		{"legacy pick selection miss", models.PowerballHistory{Category: "normalball", PickSelection: "Odd"}, drawB, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GradeBet(tt.bet, NewOutcome(tt.draw))
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("won = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGradeBetUnknownPick(t *testing.T) {
+	tests := []struct {
+		name string
+		bet  models.PowerballHistory
+	}{
+		{"unknown category", models.PowerballHistory{Category: "ladder", PickSelection: "Odd"}},
+		{"size on powerball", models.PowerballHistory{Category: "powerball", PickSelection: "Large"}},
+		{"sum on powerball", models.PowerballHistory{Category: "powerball", PickSelection: "7"}},
+		{"unknown text", models.PowerballHistory{Category: "normalball", PickSelection: "Jackpot"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			won, err := GradeBet(tt.bet, NewOutcome(drawA))
+			if !errors.Is(err, ErrUnknownPick) {
+				t.Fatalf("err = %v, want ErrUnknownPick", err)
+			}
+			if won {
+				t.Error("bet with an unknown pick must not win")
+			}
+		})
+	}
+}
+
+func TestPickKey(t *testing.T) {
+	odd := "Odd"
+	korean := "홀"
+	tests := []struct {
+		name string
+		bet  models.PowerballHistory
+		want string
+	}{
+		{"single", models.PowerballHistory{Category: "powerball", BetType: "single", BetText: &odd}, "powerball:odd"},
+		{"single korean", models.PowerballHistory{Category: "powerball", BetType: "single", BetText: &korean}, "powerball:odd"},
+		{"combination", models.PowerballHistory{Category: "oddeven", BetType: "combination", BetBalls: []models.BallOption{{Text: "Odd"}, {Text: "Even"}}}, "oddeven:odd+even"},
+		{"legacy", models.PowerballHistory{Category: "normalball", PickSelection: "Under"}, "normalball:under"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PickKey(tt.bet); got != tt.want {
+				t.Errorf("PickKey = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
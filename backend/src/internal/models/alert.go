@@ -8,7 +8,7 @@ import (
 
 type Alert struct {
 	ID          uint            `json:"id" gorm:"primaryKey"`
-	Type        string          `json:"type" gorm:"size:50;not null"` // "deposit", "withdrawal", "qna", "rollingExchange", "point", "signup"
+	Type        string          `json:"type" gorm:"size:50;not null"` // "deposit", "withdrawal", "qna", "rollingExchange", "point", "signup", "casinoLimit", "casinoShortfall", "casinoTransfer", "miniRoundLost", "roulettePrize", "casinoUnmatched", "attendanceReward"
 	Title       string          `json:"title" gorm:"size:200;not null"`
 	Message     string          `json:"message" gorm:"type:text"`
 	EntityID    uint            `json:"entityId"` // ID of the related entity (transaction ID, QNA ID, user ID, etc.)
@@ -9,15 +9,63 @@ import (
 type Attendance struct {
 	ID uint `json:"id" gorm:"primaryKey"`
 
-	UserID uint `json:"userId"`
+	UserID uint `json:"userId" gorm:"uniqueIndex:idx_attendance_user_date"`
 	User   User `gorm:"foreignKey:UserID"`
 
-	IP       string `json:"ip"`
-	DeviceID string `json:"deviceId"`
+	IP       string `json:"ip" gorm:"size:45;index"`
+	DeviceID string `json:"deviceId" gorm:"size:400;index"`
 
 	RequestCount uint `json:"requestCount"`
 
+	// Business day of the check-in (YYYY-MM-DD), one row per user per day
+	AttendanceDate string `json:"attendanceDate" gorm:"size:10;uniqueIndex:idx_attendance_user_date"`
+	Streak         int    `json:"streak" gorm:"default:1"`
+
+	// Reward paid for this check-in, if the streak hit a configured milestone
+	RewardType   string  `json:"rewardType" gorm:"size:20"` // "point", "coupon"
+	RewardAmount float64 `json:"rewardAmount" gorm:"default:0"`
+
+	CreatedAt time.Time       `json:"createdAt"`
+	UpdatedAt time.Time       `json:"updatedAt"`
+	DeletedAt *gorm.DeletedAt `gorm:"index" json:"deletedAt,omitempty"`
+}
+
+// AttendanceConfig holds the global check-in rules (single row)
+type AttendanceConfig struct {
+	ID uint `json:"id" gorm:"primaryKey"`
+
+	IsActive bool `json:"isActive" gorm:"default:true"`
+
+	// Hour (0-23) at which a new business day starts
+	DayStartHour int `json:"dayStartHour" gorm:"default:0"`
+
+	// Maximum number of distinct users that may check in per business day from one device / IP (0 = unlimited)
+	MaxPerDevice int `json:"maxPerDevice" gorm:"default:1"`
+	MaxPerIP     int `json:"maxPerIp" gorm:"default:3"`
+
 	CreatedAt time.Time       `json:"createdAt"`
 	UpdatedAt time.Time       `json:"updatedAt"`
 	DeletedAt *gorm.DeletedAt `gorm:"index" json:"deletedAt,omitempty"`
 }
+
+// AttendanceReward is paid when a user's streak reaches StreakDays
+type AttendanceReward struct {
+	ID uint `json:"id" gorm:"primaryKey"`
+
+	StreakDays int     `json:"streakDays" gorm:"not null;uniqueIndex"` // 7, 14, 28
+	RewardType string  `json:"rewardType" gorm:"size:20;not null"`     // "point", "coupon"
+	Amount     float64 `json:"amount" gorm:"not null;default:0"`       // Points, or number of coupons
+	IsActive   bool    `json:"isActive" gorm:"default:true"`
+
+	// Campaign to issue from when RewardType is "coupon"
+	CouponCampaignID *uint `json:"couponCampaignId"`
+
+	CreatedAt time.Time       `json:"createdAt"`
+	UpdatedAt time.Time       `json:"updatedAt"`
+	DeletedAt *gorm.DeletedAt `gorm:"index" json:"deletedAt,omitempty"`
+}
+
+// BusinessDay returns the business day (YYYY-MM-DD) that t falls into
+func (c AttendanceConfig) BusinessDay(t time.Time) string {
+	return t.Add(-time.Duration(c.DayStartHour) * time.Hour).Format("2006-01-02")
+}
@@ -7,21 +7,34 @@ import (
 )
 
 type CasinoBet struct {
-	ID            uint            `json:"id" gorm:"primaryKey"`
-	Type          string          `json:"type" gorm:"size:100"`
-	UserID        uint            `json:"userId"`
-	User          *User           `json:"user" gorm:"foreignKey:UserID"`
-	GameID        uint            `json:"gameId"`
-	Amount        float64         `json:"amount"`
-	Status        string          `json:"status"`
-	GameName      string          `json:"gameName"`
-	TransID       string          `json:"transId"`
-	WinningAmount uint            `json:"winningAmount"`
-	BettingTime   uint            `json:"bettingTime"`
-	Details       interface{}     `json:"details" gorm:"type:jsonb"`
-	BeforeAmount  float64         `json:"beforeAmount"`
-	AfterAmount   float64         `json:"afterAmount"`
-	CreatedAt     time.Time       `json:"createdAt"`
-	UpdatedAt     time.Time       `json:"updatedAt"`
-	DeletedAt     *gorm.DeletedAt `gorm:"index" json:"deletedAt,omitempty"`
+	ID            uint        `json:"id" gorm:"primaryKey"`
+	Type          string      `json:"type" gorm:"size:100"`
+	UserID        uint        `json:"userId"`
+	User          *User       `json:"user" gorm:"foreignKey:UserID"`
+	GameID        uint        `json:"gameId"`
+	Amount        float64     `json:"amount"`
+	Status        string      `json:"status"`
+	GameName      string      `json:"gameName"`
+	TransID       string      `json:"transId" gorm:"uniqueIndex:idx_casino_bet_trans_id,where:trans_id <> ''"`
+	WinningAmount uint        `json:"winningAmount"`
+	BettingTime   uint        `json:"bettingTime"`
+	Details       interface{} `json:"details" gorm:"type:jsonb"`
+	BeforeAmount  float64     `json:"beforeAmount"`
+	AfterAmount   float64     `json:"afterAmount"`
+	CasinoRoundID *uint       `json:"casinoRoundId" gorm:"index"`
+
+	// The game of Details, extracted at ingest for search and reporting
+	Vendor    string `json:"vendor" gorm:"size:100;index:idx_casino_bet_vendor_game,priority:1"`
+	GameType  string `json:"gameType" gorm:"size:50;index"`
+	GameCode  string `json:"gameCode" gorm:"size:100;index:idx_casino_bet_vendor_game,priority:2"` // Provider game ID
+	GameTitle string `json:"gameTitle" gorm:"size:255"`
+	RoundID   string `json:"roundId" gorm:"size:150;index"`
+
+	// When the bet was played at the provider; CreatedAt is when it was recorded here, which
+	// trails it for bets synced late. Reports filter and group by this.
+	PlayedAt time.Time `json:"playedAt" gorm:"index"`
+
+	CreatedAt time.Time       `json:"createdAt" gorm:"index"`
+	UpdatedAt time.Time       `json:"updatedAt"`
+	DeletedAt *gorm.DeletedAt `gorm:"index" json:"deletedAt,omitempty"`
 }
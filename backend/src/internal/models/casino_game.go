@@ -0,0 +1,56 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// CasinoGame is a game of the local casino catalog, synced from the providers' game lists so
+// that lobbies don't wait on the provider
+type CasinoGame struct {
+	ID uint `json:"id" gorm:"primaryKey"`
+
+	// The game as the provider knows it
+	Provider  string `json:"provider" gorm:"size:50;not null;uniqueIndex:idx_casino_game_key"`
+	Vendor    string `json:"vendor" gorm:"size:100;not null;uniqueIndex:idx_casino_game_key;index"`
+	GameID    string `json:"gameId" gorm:"size:100;not null;uniqueIndex:idx_casino_game_key"`
+	Type      string `json:"type" gorm:"size:50;index"`
+	Title     string `json:"title" gorm:"size:255"`
+	Thumbnail string `json:"thumbnail" gorm:"size:500"`
+
+	// Admin curation, kept by the sync
+	Enabled   bool   `json:"enabled" gorm:"default:true"`
+	Featured  bool   `json:"featured" gorm:"default:false"`
+	SortOrder int    `json:"sortOrder" gorm:"default:0"`
+	Tags      string `json:"tags" gorm:"size:255"` // Comma separated, e.g. "new,hot"
+
+	// Available is false once the game dropped out of the provider's list
+	Available bool      `json:"available" gorm:"default:true"`
+	SyncedAt  time.Time `json:"syncedAt"`
+
+	CreatedAt time.Time       `json:"createdAt"`
+	UpdatedAt time.Time       `json:"updatedAt"`
+	DeletedAt *gorm.DeletedAt `gorm:"index" json:"deletedAt,omitempty"`
+}
+
+// CasinoGameFavorite is a game a user starred in the lobby
+type CasinoGameFavorite struct {
+	ID           uint        `json:"id" gorm:"primaryKey"`
+	UserID       uint        `json:"userId" gorm:"not null;uniqueIndex:idx_casino_game_favorite"`
+	CasinoGameID uint        `json:"casinoGameId" gorm:"not null;uniqueIndex:idx_casino_game_favorite"`
+	CasinoGame   *CasinoGame `json:"casinoGame,omitempty" gorm:"foreignKey:CasinoGameID"`
+	CreatedAt    time.Time   `json:"createdAt"`
+}
+
+// CasinoGamePlay is the last launch of a game by a user, for the recently played list
+type CasinoGamePlay struct {
+	ID           uint        `json:"id" gorm:"primaryKey"`
+	UserID       uint        `json:"userId" gorm:"not null;uniqueIndex:idx_casino_game_play"`
+	CasinoGameID uint        `json:"casinoGameId" gorm:"not null;uniqueIndex:idx_casino_game_play"`
+	CasinoGame   *CasinoGame `json:"casinoGame,omitempty" gorm:"foreignKey:CasinoGameID"`
+	PlayCount    int         `json:"playCount" gorm:"default:0"`
+	PlayedAt     time.Time   `json:"playedAt" gorm:"index"`
+	CreatedAt    time.Time   `json:"createdAt"`
+	UpdatedAt    time.Time   `json:"updatedAt"`
+}
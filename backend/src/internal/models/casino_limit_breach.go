@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// CasinoLimitBreach records that a user hit one of their casino betting limits on a day. One
+// row per user, limit and day, so that admins are alerted once.
+type CasinoLimitBreach struct {
+	ID uint `json:"id" gorm:"primaryKey"`
+
+	UserID uint   `json:"userId" gorm:"not null;uniqueIndex:idx_casino_limit_breach"`
+	User   *User  `json:"user,omitempty" gorm:"foreignKey:UserID"`
+	Kind   string `json:"kind" gorm:"size:30;not null;uniqueIndex:idx_casino_limit_breach"` // "maxBet", "dailyTurnover" or "dailyLoss"
+	Day    string `json:"day" gorm:"size:10;not null;uniqueIndex:idx_casino_limit_breach"`  // 2006-01-02
+
+	Cap    float64 `json:"cap"`
+	Value  float64 `json:"value"`                 // What the play would have reached, or reached
+	Action string  `json:"action" gorm:"size:20"` // "rejected" when refused, "exceeded" when detected after the fact
+	Count  int     `json:"count" gorm:"default:1"`
+
+	CreatedAt time.Time       `json:"createdAt"`
+	UpdatedAt time.Time       `json:"updatedAt"`
+	DeletedAt *gorm.DeletedAt `gorm:"index" json:"deletedAt,omitempty"`
+}
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"time"
+)
+
+// CasinoRound consolidates the bets and wins a user placed in one casino game round. Rows are
+// maintained when casino bets are ingested, so that history is paginated in SQL.
+type CasinoRound struct {
+	ID     uint  `json:"id" gorm:"primaryKey"`
+	UserID uint  `json:"userId" gorm:"not null;uniqueIndex:idx_casino_round_key;index:idx_casino_round_user_created,priority:1"`
+	User   *User `json:"user" gorm:"foreignKey:UserID"`
+
+	// RoundID is the provider's round; bets without one form a round of their own keyed by
+	// "tx:<transaction id>"
+	Provider  string `json:"provider" gorm:"size:50;not null;uniqueIndex:idx_casino_round_key"`
+	Vendor    string `json:"vendor" gorm:"size:100;uniqueIndex:idx_casino_round_key"`
+	RoundID   string `json:"roundId" gorm:"size:150;not null;uniqueIndex:idx_casino_round_key"`
+	GameType  string `json:"gameType" gorm:"size:50;index"`
+	GameID    string `json:"gameId" gorm:"size:100"`
+	GameTitle string `json:"gameTitle" gorm:"size:255"`
+	GameName  string `json:"gameName" gorm:"size:200"` // "vendor|type", as on CasinoBet
+
+	BetAmount float64 `json:"betAmount"` // Sum of the bets, negative
+	WinAmount float64 `json:"winAmount"` // Sum of the wins
+	NetAmount float64 `json:"netAmount"` // WinAmount + BetAmount
+	BetCount  int     `json:"betCount" gorm:"default:0"`
+	WinCount  int     `json:"winCount" gorm:"default:0"`
+
+	BeforeAmount float64 `json:"beforeAmount"` // Balance before the first bet
+	AfterAmount  float64 `json:"afterAmount"`  // Balance after the latest transaction
+
+	// "pending" until a win (possibly of 0) settles the round, then "won" or "lost";
+	// "cancelled" once all its transactions were rolled back
+	ResultStatus string `json:"resultStatus" gorm:"size:20;default:'pending';index"`
+	Status       string `json:"status" gorm:"size:50"` // Status of the first bet
+
+	BetID       uint        `json:"betId"` // First bet
+	WinID       uint        `json:"winId"` // Latest win
+	TransID     string      `json:"transId" gorm:"size:100;index"`
+	BettingTime uint        `json:"bettingTime"`
+	Details     interface{} `json:"details" gorm:"type:jsonb"`
+	SettledAt   *time.Time  `json:"settledAt"`
+
+	CreatedAt time.Time `json:"createdAt" gorm:"index:idx_casino_round_user_created,priority:2;index"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
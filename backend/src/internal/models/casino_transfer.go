@@ -0,0 +1,48 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// CasinoTransfer moves balance between a user's profile and their casino account. The two
+// sides cannot be changed atomically, so a transfer is persisted before either side moves and
+// advances pending -> remote_confirmed -> committed. Transfers left behind by a crash or an
+// unanswered provider call are finished or compensated by the recovery worker when the
+// provider can confirm them by reference, and sent to review for admins otherwise.
+type CasinoTransfer struct {
+	ID uint `json:"id" gorm:"primaryKey"`
+
+	UserID   uint   `json:"userId" gorm:"not null;index"`
+	User     *User  `json:"user,omitempty" gorm:"foreignKey:UserID"`
+	Provider string `json:"provider" gorm:"size:50;not null"`
+
+	Direction string  `json:"direction" gorm:"size:20;not null"` // "deposit" (profile to casino) or "withdraw" (casino to profile)
+	Amount    float64 `json:"amount"`
+
+	// "pending", "remote_confirmed", "review", "committed" or "compensated"
+	Status string `json:"status" gorm:"size:20;not null;index;default:pending"`
+
+	// Casino balance read before the provider call, for admins reviewing the transfer
+	RemoteBalanceBefore float64 `json:"remoteBalanceBefore"`
+	LocalBalanceBefore  float64 `json:"localBalanceBefore"`
+	LocalBalanceAfter   float64 `json:"localBalanceAfter"`
+
+	// Ledger row of the transfer
+	TransactionID *uint `json:"transactionId"`
+
+	Attempts    int        `json:"attempts" gorm:"default:0"` // Recovery attempts
+	LastError   string     `json:"lastError" gorm:"type:text"`
+	CompletedAt *time.Time `json:"completedAt"`
+
+	CreatedAt time.Time       `json:"createdAt"`
+	UpdatedAt time.Time       `json:"updatedAt"`
+	DeletedAt *gorm.DeletedAt `gorm:"index" json:"deletedAt,omitempty"`
+}
+
+// Reference identifies the transfer to the provider, which applies a reference once
+func (t *CasinoTransfer) Reference() string {
+	return fmt.Sprintf("casino-transfer-%d", t.ID)
+}
@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// CasinoVendor holds the admin controls of a casino vendor: whether it can be played, its
+// maintenance window and who may play it. Vendors without a record are open to everyone the
+// level's game access allows.
+type CasinoVendor struct {
+	ID uint `json:"id" gorm:"primaryKey"`
+
+	Vendor   string `json:"vendor" gorm:"size:100;not null;uniqueIndex"` // Lower cased, without spaces
+	Name     string `json:"name" gorm:"size:100"`
+	Category string `json:"category" gorm:"size:20"` // "live", "slot" or "holdem", empty to infer from the game
+	Enabled  bool   `json:"enabled" gorm:"default:true"`
+	OrderNum uint   `json:"orderNum" gorm:"default:1"`
+
+	// The vendor is under maintenance from MaintenanceStart until MaintenanceEnd, or until
+	// further notice when MaintenanceEnd is nil
+	MaintenanceStart   *time.Time `json:"maintenanceStart"`
+	MaintenanceEnd     *time.Time `json:"maintenanceEnd"`
+	MaintenanceMessage string     `json:"maintenanceMessage" gorm:"type:text"` // Shown to users while unavailable
+
+	// Restrictions, zero values allow everyone
+	MinLevel  int32     `json:"minLevel" gorm:"default:0"`
+	DomainIDs UintArray `json:"domainIds" gorm:"type:integer[]"`
+
+	CreatedAt time.Time       `json:"createdAt"`
+	UpdatedAt time.Time       `json:"updatedAt"`
+	DeletedAt *gorm.DeletedAt `gorm:"index" json:"deletedAt,omitempty"`
+}
+
+// InMaintenance reports whether the vendor's maintenance window covers t
+func (v CasinoVendor) InMaintenance(t time.Time) bool {
+	if v.MaintenanceStart == nil || t.Before(*v.MaintenanceStart) {
+		return false
+	}
+	return v.MaintenanceEnd == nil || t.Before(*v.MaintenanceEnd)
+}
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+)
+
+// CasinoWalletTransaction is one seamless-wallet callback applied to a user's balance. The
+// provider transaction ID is unique, so a retried callback returns the recorded result.
+type CasinoWalletTransaction struct {
+	ID uint `json:"id" gorm:"primaryKey"`
+
+	Provider      string `json:"provider" gorm:"size:50;uniqueIndex:idx_casino_wallet_tx"`
+	TransactionID string `json:"transactionId" gorm:"size:100;uniqueIndex:idx_casino_wallet_tx"`
+	Action        string `json:"action" gorm:"size:20"` // "debit", "credit" or "rollback"
+
+	UserID uint  `json:"userId" gorm:"index"`
+	User   *User `json:"user,omitempty" gorm:"foreignKey:UserID"`
+
+	RoundID  string `json:"roundId" gorm:"size:100;index"`
+	Vendor   string `json:"vendor" gorm:"size:100"`
+	GameType string `json:"gameType" gorm:"size:50"`
+	GameID   string `json:"gameId" gorm:"size:100"`
+
+	Amount        float64 `json:"amount"`
+	BalanceBefore float64 `json:"balanceBefore"`
+	BalanceAfter  float64 `json:"balanceAfter"`
+
+	// For rollbacks, the provider transaction rolled back. A rollback of a transaction not
+	// seen yet stands as its tombstone: the transaction is recorded as rolled back on arrival.
+	ReferenceID string `json:"referenceId" gorm:"size:100;index"`
+	// Set on a debit or credit once it has been rolled back
+	RolledBack bool `json:"rolledBack" gorm:"default:false"`
+	// For rollbacks of a win, the part the balance could not cover, left to admins
+	Shortfall float64 `json:"shortfall"`
+
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
@@ -0,0 +1,86 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// CouponCampaign defines a kind of coupon that admins can issue to users
+type CouponCampaign struct {
+	ID uint `json:"id" gorm:"primaryKey"`
+
+	Name        string `json:"name" gorm:"size:100;not null"`
+	Description string `json:"description" gorm:"type:text"`
+
+	// "cash" - Amount is added to balance
+	// "point" - Amount is added to points
+	// "deposit_match" - Amount is a percent of the user's latest approved deposit, capped by MaxAmount
+	Type      string  `json:"type" gorm:"size:20;not null"`
+	Amount    float64 `json:"amount" gorm:"not null;default:0"`
+	MaxAmount float64 `json:"maxAmount" gorm:"default:0"` // 0 = no cap (deposit_match only)
+
+	// Optional code users can enter to claim the coupon themselves
+	Code *string `json:"code" gorm:"size:50;uniqueIndex"`
+
+	// Level restriction on Profile.Level (0 = no restriction)
+	MinLevel int `json:"minLevel" gorm:"default:0"`
+	MaxLevel int `json:"maxLevel" gorm:"default:0"`
+
+	PerUserLimit int `json:"perUserLimit" gorm:"default:1"` // 0 = unlimited
+	TotalLimit   int `json:"totalLimit" gorm:"default:0"`   // 0 = unlimited
+
+	ExpiresAt *time.Time `json:"expiresAt"`
+	IsActive  bool       `json:"isActive" gorm:"default:true"`
+
+	IssuedCount   int `json:"issuedCount" gorm:"default:0"`
+	RedeemedCount int `json:"redeemedCount" gorm:"default:0"`
+
+	CreatedAt time.Time       `json:"createdAt"`
+	UpdatedAt time.Time       `json:"updatedAt"`
+	DeletedAt *gorm.DeletedAt `gorm:"index" json:"deletedAt,omitempty"`
+}
+
+// UserCoupon is a coupon issued to a single user
+type UserCoupon struct {
+	ID uint `json:"id" gorm:"primaryKey"`
+
+	CampaignID uint           `json:"campaignId" gorm:"index;not null"`
+	Campaign   CouponCampaign `json:"campaign" gorm:"foreignKey:CampaignID"`
+
+	UserID uint  `json:"userId" gorm:"index;not null"`
+	User   *User `json:"user,omitempty" gorm:"foreignKey:UserID"`
+
+	Status string `json:"status" gorm:"size:20;default:'issued';index"` // "issued", "redeemed", "expired", "revoked"
+	Source string `json:"source" gorm:"size:20"`                        // "admin", "level", "code", "attendance", "roulette"
+
+	IssuedBy   *uint      `json:"issuedBy"` // Admin user ID for manual issuance
+	ExpiresAt  *time.Time `json:"expiresAt"`
+	RedeemedAt *time.Time `json:"redeemedAt"`
+
+	CreatedAt time.Time       `json:"createdAt"`
+	UpdatedAt time.Time       `json:"updatedAt"`
+	DeletedAt *gorm.DeletedAt `gorm:"index" json:"deletedAt,omitempty"`
+}
+
+// CouponRedemption records the ledger effect of redeeming a coupon
+type CouponRedemption struct {
+	ID uint `json:"id" gorm:"primaryKey"`
+
+	UserCouponID  uint `json:"userCouponId" gorm:"uniqueIndex;not null"`
+	CampaignID    uint `json:"campaignId" gorm:"index"`
+	UserID        uint `json:"userId" gorm:"index"`
+	TransactionID uint `json:"transactionId"`
+
+	Type   string  `json:"type" gorm:"size:20"`
+	Amount float64 `json:"amount"`
+
+	BalanceBefore float64 `json:"balanceBefore"`
+	BalanceAfter  float64 `json:"balanceAfter"`
+	PointBefore   float64 `json:"pointBefore"`
+	PointAfter    float64 `json:"pointAfter"`
+
+	CreatedAt time.Time       `json:"createdAt"`
+	UpdatedAt time.Time       `json:"updatedAt"`
+	DeletedAt *gorm.DeletedAt `gorm:"index" json:"deletedAt,omitempty"`
+}
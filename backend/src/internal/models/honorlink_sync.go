@@ -0,0 +1,68 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// HonorLinkSyncState is the single-row checkpoint of the HonorLink transaction sync
+type HonorLinkSyncState struct {
+	ID uint `json:"id" gorm:"primaryKey"`
+
+	// Transactions created before Cursor have been synced
+	Cursor time.Time `json:"cursor"`
+
+	LastRunAt     *time.Time `json:"lastRunAt"`
+	LastSuccessAt *time.Time `json:"lastSuccessAt"`
+	LastError     string     `json:"lastError" gorm:"type:text"`
+	LastFetched   int        `json:"lastFetched"`   // Transactions fetched by the last run
+	LastProcessed int        `json:"lastProcessed"` // Of which new and recorded
+
+	CreatedAt time.Time       `json:"createdAt"`
+	UpdatedAt time.Time       `json:"updatedAt"`
+	DeletedAt *gorm.DeletedAt `gorm:"index" json:"deletedAt,omitempty"`
+}
+
+// HonorLinkBackfill is an admin request to re-sync HonorLink transactions of a time range.
+// The sync picks up pending backfills and works through them a window at a time.
+type HonorLinkBackfill struct {
+	ID uint `json:"id" gorm:"primaryKey"`
+
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+
+	Status    string    `json:"status" gorm:"size:20;index;default:pending"` // "pending", "running", "done" or "failed"
+	Progress  time.Time `json:"progress"`                                    // Synced up to here
+	Fetched   int       `json:"fetched"`
+	Processed int       `json:"processed"`
+	Error     string    `json:"error" gorm:"type:text"`
+
+	RequestedBy *uint `json:"requestedBy"`
+	Requester   *User `json:"requester,omitempty" gorm:"foreignKey:RequestedBy"`
+
+	CreatedAt time.Time       `json:"createdAt"`
+	UpdatedAt time.Time       `json:"updatedAt"`
+	DeletedAt *gorm.DeletedAt `gorm:"index" json:"deletedAt,omitempty"`
+}
+
+// HonorLinkUnmatchedTransaction is a HonorLink transaction the sync skipped because it names no
+// local user. It is kept for admins, and removed once a backfill records it after all.
+type HonorLinkUnmatchedTransaction struct {
+	ID uint `json:"id" gorm:"primaryKey"`
+
+	TransactionID string  `json:"transactionId" gorm:"size:100;uniqueIndex"`
+	Username      string  `json:"username" gorm:"size:100;index"`
+	Type          string  `json:"type" gorm:"size:20"`
+	Amount        float64 `json:"amount"`
+	Vendor        string  `json:"vendor" gorm:"size:100"`
+	GameType      string  `json:"gameType" gorm:"size:50"`
+	GameID        string  `json:"gameId" gorm:"size:100"`
+	Reason        string  `json:"reason" gorm:"size:255"`
+
+	PlayedAt time.Time `json:"playedAt"`
+
+	CreatedAt time.Time       `json:"createdAt"`
+	UpdatedAt time.Time       `json:"updatedAt"`
+	DeletedAt *gorm.DeletedAt `gorm:"index" json:"deletedAt,omitempty"`
+}
@@ -72,6 +72,11 @@ type Level struct {
 	HoldemPokerMaximumRolling float64 `json:"holdemPokerMaximumRolling" gorm:"default:0"`
 	HoldemPokerMinimumRolling float64 `json:"holdemPokerMinimumRolling" gorm:"default:0"`
 
+	// Casino Betting Limits, 0 for no limit
+	CasinoMaxBet             float64 `json:"casinoMaxBet" gorm:"default:0"` // Stake per round
+	CasinoDailyTurnoverLimit float64 `json:"casinoDailyTurnoverLimit" gorm:"default:0"`
+	CasinoDailyLossLimit     float64 `json:"casinoDailyLossLimit" gorm:"default:0"`
+
 	// Sports Rolling Settings
 	SportsMaxRolling     float64 `json:"sportsMaxRolling" gorm:"default:0"`
 	SportsMinimumRolling float64 `json:"sportsMinimumRolling" gorm:"default:0"`
@@ -71,6 +71,39 @@ type MiniGameConfig struct {
 	DeletedAt *gorm.DeletedAt `gorm:"index" json:"deletedAt,omitempty"`
 }
 
+// DefaultMiniBetOptions returns the default betting options of a game type for level
+func DefaultMiniBetOptions(gameType string, level int) []MiniBetOption {
+	// Powerball options
+	powerballOptions := []MiniBetOption{
+		{Name: "Powerball Odd", Odds: "1.95", Type: "single", Ball: stringPtr("blue"), Text: stringPtr("Odd"), GameType: gameType, Category: "powerball", Level: level, Enabled: true, OrderNum: 1},
+		{Name: "Powerball Even", Odds: "1.95", Type: "single", Ball: stringPtr("red"), Text: stringPtr("Even"), GameType: gameType, Category: "powerball", Level: level, Enabled: true, OrderNum: 2},
+		{Name: "Powerball Under", Odds: "1.95", Type: "single", Ball: stringPtr("blue"), Text: stringPtr("Under"), GameType: gameType, Category: "powerball", Level: level, Enabled: true, OrderNum: 3},
+		{Name: "Powerball Over", Odds: "1.95", Type: "single", Ball: stringPtr("red"), Text: stringPtr("Over"), GameType: gameType, Category: "powerball", Level: level, Enabled: true, OrderNum: 4},
+		{Name: "PaOdd-PaUnder", Odds: "4.1", Type: "combination", Balls: []BallOption{{Color: "blue", Text: "Odd"}, {Color: "blue", Text: "Under"}}, GameType: gameType, Category: "powerball", Level: level, Enabled: true, OrderNum: 5},
+		{Name: "PaOdd-PaOver", Odds: "3.1", Type: "combination", Balls: []BallOption{{Color: "blue", Text: "Odd"}, {Color: "red", Text: "Over"}}, GameType: gameType, Category: "powerball", Level: level, Enabled: true, OrderNum: 6},
+		{Name: "PaEven-PaUnder", Odds: "3.1", Type: "combination", Balls: []BallOption{{Color: "red", Text: "Even"}, {Color: "blue", Text: "Under"}}, GameType: gameType, Category: "powerball", Level: level, Enabled: true, OrderNum: 7},
+		{Name: "PaEven-PaOver", Odds: "4.1", Type: "combination", Balls: []BallOption{{Color: "red", Text: "Even"}, {Color: "red", Text: "Over"}}, GameType: gameType, Category: "powerball", Level: level, Enabled: true, OrderNum: 8},
+	}
+
+	// Normalball options
+	normalballOptions := []MiniBetOption{
+		{Name: "Normalball Odd", Odds: "1.95", Type: "single", Ball: stringPtr("blue"), Text: stringPtr("Odd"), GameType: gameType, Category: "normalball", Level: level, Enabled: true, OrderNum: 9},
+		{Name: "Normalball Even", Odds: "1.95", Type: "single", Ball: stringPtr("red"), Text: stringPtr("Even"), GameType: gameType, Category: "normalball", Level: level, Enabled: true, OrderNum: 10},
+		{Name: "Normalball Under", Odds: "1.95", Type: "single", Ball: stringPtr("blue"), Text: stringPtr("Under"), GameType: gameType, Category: "normalball", Level: level, Enabled: true, OrderNum: 11},
+		{Name: "Normalball Over", Odds: "1.95", Type: "single", Ball: stringPtr("red"), Text: stringPtr("Over"), GameType: gameType, Category: "normalball", Level: level, Enabled: true, OrderNum: 12},
+		{Name: "N-NUnder", Odds: "4.1", Type: "combination", Balls: []BallOption{{Color: "blue", Text: "Odd"}, {Color: "blue", Text: "Under"}}, GameType: gameType, Category: "normalball", Level: level, Enabled: true, OrderNum: 13},
+		{Name: "N-NOver", Odds: "3.1", Type: "combination", Balls: []BallOption{{Color: "blue", Text: "Odd"}, {Color: "red", Text: "Over"}}, GameType: gameType, Category: "normalball", Level: level, Enabled: true, OrderNum: 14},
+		{Name: "NOdd-NUnder", Odds: "3.1", Type: "combination", Balls: []BallOption{{Color: "red", Text: "Even"}, {Color: "blue", Text: "Under"}}, GameType: gameType, Category: "normalball", Level: level, Enabled: true, OrderNum: 15},
+		{Name: "NEven-NOver", Odds: "4.1", Type: "combination", Balls: []BallOption{{Color: "red", Text: "Even"}, {Color: "red", Text: "Over"}}, GameType: gameType, Category: "normalball", Level: level, Enabled: true, OrderNum: 16},
+	}
+
+	return append(powerballOptions, normalballOptions...)
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
 // BeforeCreate hook to serialize balls to JSON
 func (m *MiniBetOption) BeforeCreate(tx *gorm.DB) error {
 	if len(m.Balls) > 0 {
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// MiniGameDraw is the result of one mini-game round. Every draw is stored once,
+// whether or not it had bets; bets reference it through PowerballHistory.DrawID.
+type MiniGameDraw struct {
+	ID uint `json:"id" gorm:"primaryKey"`
+
+	GameType string `json:"gameType" gorm:"size:20;uniqueIndex:idx_mini_game_draw_round"`
+	DrawDate string `json:"date" gorm:"size:10;uniqueIndex:idx_mini_game_draw_round"` // Game day (YYYY-MM-DD, KST)
+	Round    uint   `json:"round" gorm:"uniqueIndex:idx_mini_game_draw_round"`
+
+	Times          uint64    `json:"times"`
+	FixedDateRound string    `json:"fixedDateRound"`
+	DrawnAt        time.Time `json:"drawnAt" gorm:"index"`
+
+	// Ball numbers (first 5 are default balls, last is powerball)
+	Ball1     int `json:"ball1"`
+	Ball2     int `json:"ball2"`
+	Ball3     int `json:"ball3"`
+	Ball4     int `json:"ball4"`
+	Ball5     int `json:"ball5"`
+	PowerBall int `json:"powerBall" gorm:"column:power_ball"`
+
+	// Powerball characteristics
+	PowBallOddEven   string `json:"powBallOe" gorm:"column:pow_ball_oe"`
+	PowBallUnderOver string `json:"powBallUnover" gorm:"column:pow_ball_unover"`
+
+	// Default ball characteristics
+	DefBallSum       int    `json:"defBallSum" gorm:"column:def_ball_sum"`
+	DefBallOddEven   string `json:"defBallOe" gorm:"column:def_ball_oe"`
+	DefBallUnderOver string `json:"defBallUnover" gorm:"column:def_ball_unover"`
+	DefBallSize      string `json:"defBallSize" gorm:"column:def_ball_size"`
+	DefBallSection   string `json:"defBallSection" gorm:"column:def_ball_section"`
+
+	CreatedAt time.Time       `json:"createdAt"`
+	UpdatedAt time.Time       `json:"updatedAt"`
+	DeletedAt *gorm.DeletedAt `gorm:"index" json:"deletedAt,omitempty"`
+}
@@ -0,0 +1,58 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// MiniGameExposureLimit caps the potential payout the house accepts on one round of a game type
+type MiniGameExposureLimit struct {
+	ID uint `json:"id" gorm:"primaryKey"`
+
+	GameType string `json:"gameType" gorm:"size:20;uniqueIndex"`
+
+	MaxPickPayout  float64 `json:"maxPickPayout" gorm:"default:0"`  // Per pick and round, 0 = no cap
+	MaxRoundPayout float64 `json:"maxRoundPayout" gorm:"default:0"` // All picks of a round, 0 = no cap
+
+	// What happens when a bet would exceed the pick cap:
+	// "reject" rejects that bet, "suspend" also closes the pick for the rest of the round
+	Action string `json:"action" gorm:"size:20;default:'reject'"`
+
+	CreatedAt time.Time       `json:"createdAt"`
+	UpdatedAt time.Time       `json:"updatedAt"`
+	DeletedAt *gorm.DeletedAt `gorm:"index" json:"deletedAt,omitempty"`
+}
+
+// MiniGameExposure is the running book of one pick in one round. The row with an empty
+// PickKey holds the round total.
+type MiniGameExposure struct {
+	ID uint `json:"id" gorm:"primaryKey"`
+
+	GameType string `json:"gameType" gorm:"size:20;uniqueIndex:idx_mini_game_exposure"`
+	DrawDate string `json:"date" gorm:"size:10;uniqueIndex:idx_mini_game_exposure"`
+	Round    uint   `json:"round" gorm:"uniqueIndex:idx_mini_game_exposure"`
+	PickKey  string `json:"pickKey" gorm:"size:100;uniqueIndex:idx_mini_game_exposure"`
+
+	Bets   int     `json:"bets" gorm:"default:0"`
+	Stake  float64 `json:"stake" gorm:"default:0"`
+	Payout float64 `json:"payout" gorm:"default:0"` // Potential payout if the pick wins
+
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// MiniGameSuspension closes one pick for the rest of a round
+type MiniGameSuspension struct {
+	ID uint `json:"id" gorm:"primaryKey"`
+
+	GameType string `json:"gameType" gorm:"size:20;uniqueIndex:idx_mini_game_suspension"`
+	DrawDate string `json:"date" gorm:"size:10;uniqueIndex:idx_mini_game_suspension"`
+	Round    uint   `json:"round" gorm:"uniqueIndex:idx_mini_game_suspension"`
+	PickKey  string `json:"pickKey" gorm:"size:100;uniqueIndex:idx_mini_game_suspension"`
+
+	Reason      string `json:"reason" gorm:"size:20"` // "auto" or "admin"
+	SuspendedBy *uint  `json:"suspendedBy"`           // Admin user for manual suspensions
+
+	CreatedAt time.Time `json:"createdAt"`
+}
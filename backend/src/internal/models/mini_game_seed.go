@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// MiniGameSeed is the commit-reveal seed of one round of an in-house mini-game.
+// The hash is published before the round; the seed is revealed once the round is drawn.
+type MiniGameSeed struct {
+	ID uint `json:"id" gorm:"primaryKey"`
+
+	GameType string `json:"gameType" gorm:"size:20;uniqueIndex:idx_mini_game_seed_round"`
+	DrawDate string `json:"date" gorm:"size:10;uniqueIndex:idx_mini_game_seed_round"` // Game day (YYYY-MM-DD, KST)
+	Round    uint   `json:"round" gorm:"uniqueIndex:idx_mini_game_seed_round"`
+
+	ServerSeed     string     `json:"-" gorm:"size:64;not null"`
+	ServerSeedHash string     `json:"serverSeedHash" gorm:"size:64;not null"`
+	RevealedAt     *time.Time `json:"revealedAt"`
+
+	CreatedAt time.Time       `json:"createdAt"`
+	UpdatedAt time.Time       `json:"updatedAt"`
+	DeletedAt *gorm.DeletedAt `gorm:"index" json:"deletedAt,omitempty"`
+}
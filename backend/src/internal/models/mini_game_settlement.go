@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// MiniGameSettlementConfig is the single-row config of mini-game settlement
+type MiniGameSettlementConfig struct {
+	ID uint `json:"id" gorm:"primaryKey"`
+
+	// Rounds still without a result this long after the draw are cancelled and refunded, 0 = never
+	CancelAfterMinutes int `json:"cancelAfterMinutes" gorm:"default:30"`
+
+	CreatedAt time.Time       `json:"createdAt"`
+	UpdatedAt time.Time       `json:"updatedAt"`
+	DeletedAt *gorm.DeletedAt `gorm:"index" json:"deletedAt,omitempty"`
+}
+
+// MiniGameRoundAction is the audit trail of rounds cancelled or resulted other than by the feed
+type MiniGameRoundAction struct {
+	ID uint `json:"id" gorm:"primaryKey"`
+
+	GameType string `json:"gameType" gorm:"size:20;index:idx_mini_game_round_action"`
+	DrawDate string `json:"date" gorm:"size:10;index:idx_mini_game_round_action"`
+	Round    uint   `json:"round" gorm:"index:idx_mini_game_round_action"`
+
+	Action  string `json:"action" gorm:"size:20"` // "timeout_cancel", "cancel", "result" or "regrade"
+	AdminID *uint  `json:"adminId"`               // Nil for automatic actions
+	Admin   *User  `json:"admin,omitempty" gorm:"foreignKey:AdminID"`
+	Reason  string `json:"reason" gorm:"type:text"`
+	Details string `json:"details" gorm:"type:text"` // Entered result as JSON for "result" and "regrade"
+
+	Bets   int     `json:"bets"`   // Bets cancelled or settled
+	Amount float64 `json:"amount"` // Stakes refunded or settled
+
+	CreatedAt time.Time `json:"createdAt"`
+}
@@ -0,0 +1,43 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// MiniOddsVersion is a set of odds changes to the bet options of a game type, taking effect at
+// EffectiveFrom. The odds of an option at any time are those of the latest effective version
+// changing it, or MiniBetOption.Odds when no version does.
+type MiniOddsVersion struct {
+	ID uint `json:"id" gorm:"primaryKey"`
+
+	GameType      string    `json:"gameType" gorm:"size:50;index:idx_mini_odds_version"`
+	Version       int       `json:"version"` // Sequential per game type
+	EffectiveFrom time.Time `json:"effectiveFrom" gorm:"index:idx_mini_odds_version"`
+	Note          string    `json:"note" gorm:"type:text"`
+
+	// When MiniBetOption.Odds was rolled forward to this version, nil until it takes effect
+	AppliedAt *time.Time `json:"appliedAt" gorm:"index"`
+
+	CreatedBy *uint `json:"createdBy"`
+	Creator   *User `json:"creator,omitempty" gorm:"foreignKey:CreatedBy"`
+
+	Changes []MiniOddsChange `json:"changes,omitempty" gorm:"foreignKey:VersionID"`
+
+	CreatedAt time.Time       `json:"createdAt"`
+	UpdatedAt time.Time       `json:"updatedAt"`
+	DeletedAt *gorm.DeletedAt `gorm:"index" json:"deletedAt,omitempty"`
+}
+
+// MiniOddsChange is the new odds of one bet option in a version
+type MiniOddsChange struct {
+	ID uint `json:"id" gorm:"primaryKey"`
+
+	VersionID uint           `json:"versionId" gorm:"index"`
+	OptionID  uint           `json:"optionId" gorm:"index"`
+	Option    *MiniBetOption `json:"option,omitempty" gorm:"foreignKey:OptionID"`
+
+	PreviousOdds string `json:"previousOdds" gorm:"size:20"` // Odds in effect when the version was created
+	Odds         string `json:"odds" gorm:"size:20"`
+}
@@ -21,3 +21,24 @@ type Post struct {
 	UpdatedAt time.Time       `json:"updatedAt"`
 	DeletedAt *gorm.DeletedAt `gorm:"index" json:"deletedAt,omitempty"`
 }
+
+// PostPointAward records points paid for writing a post or comment
+type PostPointAward struct {
+	ID uint `json:"id" gorm:"primaryKey"`
+
+	UserID    uint  `json:"userId" gorm:"index:idx_post_point_award_user_date"`
+	PostID    uint  `json:"postId" gorm:"index"`
+	CommentID *uint `json:"commentId"`
+
+	AwardDate     string  `json:"awardDate" gorm:"size:10;index:idx_post_point_award_user_date"` // YYYY-MM-DD
+	Amount        float64 `json:"amount"`
+	TransactionID uint    `json:"transactionId"`
+
+	// Set when the points are taken back because the post was deleted as spam
+	ClawbackTransactionID *uint      `json:"clawbackTransactionId"`
+	ClawedBackAt          *time.Time `json:"clawedBackAt"`
+
+	CreatedAt time.Time       `json:"createdAt"`
+	UpdatedAt time.Time       `json:"updatedAt"`
+	DeletedAt *gorm.DeletedAt `gorm:"index" json:"deletedAt,omitempty"`
+}
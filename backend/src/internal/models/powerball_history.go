@@ -25,33 +25,21 @@ type PowerballHistory struct {
 	BetBallsJSON string       `json:"-" gorm:"type:text"`     // For combination bets
 	BetBalls     []BallOption `json:"betBalls" gorm:"-"`      // Not stored in DB, populated from JSON
 
+	// Normalized category and picks, see minigame.PickKey
+	PickKey string `json:"pickKey" gorm:"size:100"`
+
+	// Odds version in effect when the bet was placed, nil before odds were versioned
+	OddsVersionID *uint `json:"oddsVersionId" gorm:"index"`
+
 	Result string `json:"result"`
 	Status string `json:"status"`
 	Round  uint   `json:"round"`
 
-	// Drawing result fields
-	DrawingDate    string `json:"date" gorm:"column:drawing_date"`
-	Times          uint64 `json:"times"`
-	FixedDateRound string `json:"fixedDateRound" gorm:"column:fixed_date_round"`
-
-	// Ball numbers (first 5 are default balls, last is powerball)
-	Ball1     int `json:"ball1"`
-	Ball2     int `json:"ball2"`
-	Ball3     int `json:"ball3"`
-	Ball4     int `json:"ball4"`
-	Ball5     int `json:"ball5"`
-	PowerBall int `json:"powerBall" gorm:"column:power_ball"`
-
-	// Powerball characteristics
-	PowBallOddEven   string `json:"powBallOe" gorm:"column:pow_ball_oe"`
-	PowBallUnderOver string `json:"powBallUnover" gorm:"column:pow_ball_unover"`
-
-	// Default ball characteristics
-	DefBallSum       int    `json:"defBallSum" gorm:"column:def_ball_sum"`
-	DefBallOddEven   string `json:"defBallOe" gorm:"column:def_ball_oe"`
-	DefBallUnderOver string `json:"defBallUnover" gorm:"column:def_ball_unover"`
-	DefBallSize      string `json:"defBallSize" gorm:"column:def_ball_size"`
-	DefBallSection   string `json:"defBallSection" gorm:"column:def_ball_section"`
+	// Draw the bet was settled against, set at settlement. Bets settled before draws had their
+	// own table are linked from their old draw columns at startup, so those columns must stay in
+	// the database until that migration has run.
+	DrawID *uint         `json:"drawId" gorm:"index"`
+	Draw   *MiniGameDraw `json:"draw,omitempty" gorm:"foreignKey:DrawID"`
 
 	CreatedAt time.Time       `json:"createdAt"`
 	UpdatedAt time.Time       `json:"updatedAt"`
@@ -0,0 +1,154 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// RouletteConfig holds the global roulette rules (single row)
+type RouletteConfig struct {
+	ID uint `json:"id" gorm:"primaryKey"`
+
+	IsActive bool `json:"isActive" gorm:"default:true"`
+
+	// Free spins granted once per day
+	DailySpins int `json:"dailySpins" gorm:"default:1"`
+
+	// One spin per DepositSpinUnit of an approved deposit, capped at MaxDepositSpins per deposit (0 = disabled)
+	DepositSpinUnit float64 `json:"depositSpinUnit" gorm:"default:0"`
+	MaxDepositSpins int     `json:"maxDepositSpins" gorm:"default:0"`
+
+	// Spins granted for each attendance check-in
+	AttendanceSpins int `json:"attendanceSpins" gorm:"default:0"`
+
+	// Days an earned spin stays valid (0 = never expires)
+	SpinValidDays int `json:"spinValidDays" gorm:"default:7"`
+
+	CreatedAt time.Time       `json:"createdAt"`
+	UpdatedAt time.Time       `json:"updatedAt"`
+	DeletedAt *gorm.DeletedAt `gorm:"index" json:"deletedAt,omitempty"`
+}
+
+// RouletteSegment is one slice of the wheel
+type RouletteSegment struct {
+	ID uint `json:"id" gorm:"primaryKey"`
+
+	Label     string `json:"label" gorm:"size:100;not null"`
+	Color     string `json:"color" gorm:"size:20"`
+	SortOrder int    `json:"sortOrder" gorm:"default:0"`
+
+	// Relative probability of landing on this segment
+	Weight int `json:"weight" gorm:"not null;default:1"`
+
+	PrizeType string  `json:"prizeType" gorm:"size:20;not null"` // "point", "coupon", "cash", "none"
+	Amount    float64 `json:"amount" gorm:"default:0"`           // Points, cash, or number of coupons
+
+	// Campaign to issue from when PrizeType is "coupon"
+	CouponCampaignID *uint `json:"couponCampaignId"`
+
+	IsActive bool `json:"isActive" gorm:"default:true"`
+
+	CreatedAt time.Time       `json:"createdAt"`
+	UpdatedAt time.Time       `json:"updatedAt"`
+	DeletedAt *gorm.DeletedAt `gorm:"index" json:"deletedAt,omitempty"`
+}
+
+// RouletteEntitlement is a batch of spins granted to a user from one source
+type RouletteEntitlement struct {
+	ID uint `json:"id" gorm:"primaryKey"`
+
+	UserID uint `json:"userId" gorm:"uniqueIndex:idx_roulette_entitlement_source"`
+
+	Source    string `json:"source" gorm:"size:20;uniqueIndex:idx_roulette_entitlement_source"`    // "daily", "deposit", "attendance", "admin"
+	SourceRef string `json:"sourceRef" gorm:"size:50;uniqueIndex:idx_roulette_entitlement_source"` // Date, transaction ID or attendance ID
+
+	Spins     int        `json:"spins" gorm:"not null"`
+	UsedSpins int        `json:"usedSpins" gorm:"default:0"`
+	ExpiresAt *time.Time `json:"expiresAt" gorm:"index"`
+
+	CreatedAt time.Time       `json:"createdAt"`
+	UpdatedAt time.Time       `json:"updatedAt"`
+	DeletedAt *gorm.DeletedAt `gorm:"index" json:"deletedAt,omitempty"`
+}
+
+// RouletteSeed is a user's server/client seed pair. The server seed is only
+// revealed once the seed is rotated, so spins made with it can be verified.
+type RouletteSeed struct {
+	ID uint `json:"id" gorm:"primaryKey"`
+
+	UserID uint `json:"userId" gorm:"index"`
+
+	ServerSeed     string `json:"-" gorm:"size:64;not null"`
+	ServerSeedHash string `json:"serverSeedHash" gorm:"size:64;not null"`
+	ClientSeed     string `json:"clientSeed" gorm:"size:64;not null"`
+	Nonce          uint   `json:"nonce" gorm:"default:0"`
+
+	IsActive   bool       `json:"isActive" gorm:"default:true;index"`
+	RevealedAt *time.Time `json:"revealedAt"`
+
+	CreatedAt time.Time       `json:"createdAt"`
+	UpdatedAt time.Time       `json:"updatedAt"`
+	DeletedAt *gorm.DeletedAt `gorm:"index" json:"deletedAt,omitempty"`
+}
+
+// RouletteSpin records one spin, the inputs used to draw it and the prize paid
+type RouletteSpin struct {
+	ID uint `json:"id" gorm:"primaryKey"`
+
+	UserID uint  `json:"userId" gorm:"index"`
+	User   *User `json:"user,omitempty" gorm:"foreignKey:UserID"`
+
+	EntitlementID uint `json:"entitlementId"`
+
+	SeedID uint `json:"seedId" gorm:"uniqueIndex:idx_roulette_spin_seed_nonce"`
+	Nonce  uint `json:"nonce" gorm:"uniqueIndex:idx_roulette_spin_seed_nonce"`
+
+	// Segments (id and weight, in draw order) as they were at spin time
+	SegmentsJSON string                  `json:"-" gorm:"type:text"`
+	Segments     []RouletteSegmentWeight `json:"segments" gorm:"-"`
+	Roll         float64                 `json:"roll"`
+
+	SegmentID uint    `json:"segmentId"`
+	Label     string  `json:"label" gorm:"size:100"`
+	PrizeType string  `json:"prizeType" gorm:"size:20"`
+	Amount    float64 `json:"amount"`
+
+	TransactionID *uint `json:"transactionId"`
+	UserCouponID  *uint `json:"userCouponId"`
+
+	// "paid", or "prize_failed" when the prize could not be issued and is left to admins
+	Status     string `json:"status" gorm:"size:20;default:'paid'"`
+	PrizeError string `json:"prizeError,omitempty" gorm:"size:255"`
+
+	CreatedAt time.Time       `json:"createdAt"`
+	UpdatedAt time.Time       `json:"updatedAt"`
+	DeletedAt *gorm.DeletedAt `gorm:"index" json:"deletedAt,omitempty"`
+}
+
+// RouletteSegmentWeight is the part of a segment that decides a draw
+type RouletteSegmentWeight struct {
+	ID     uint `json:"id"`
+	Weight int  `json:"weight"`
+}
+
+// BeforeCreate hook to serialize segments to JSON
+func (s *RouletteSpin) BeforeCreate(tx *gorm.DB) error {
+	if len(s.Segments) > 0 {
+		segmentsJSON, err := json.Marshal(s.Segments)
+		if err != nil {
+			return err
+		}
+		s.SegmentsJSON = string(segmentsJSON)
+	}
+	return nil
+}
+
+// AfterFind hook to deserialize segments from JSON
+func (s *RouletteSpin) AfterFind(tx *gorm.DB) error {
+	if s.SegmentsJSON != "" {
+		return json.Unmarshal([]byte(s.SegmentsJSON), &s.Segments)
+	}
+	return nil
+}
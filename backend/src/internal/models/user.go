@@ -163,6 +163,11 @@ type User struct {
 	Slot float64 `json:"slot" gorm:"default:1.0"`
 	Hold float64 `json:"hold" gorm:"default:0"`
 
+	// Casino betting limits overriding the level's, 0 to use the level's
+	CasinoMaxBet             float64 `json:"casinoMaxBet" gorm:"default:0"`
+	CasinoDailyTurnoverLimit float64 `json:"casinoDailyTurnoverLimit" gorm:"default:0"`
+	CasinoDailyLossLimit     float64 `json:"casinoDailyLossLimit" gorm:"default:0"`
+
 	EntireLosing     float64 `json:"entireLosing" gorm:"default:0"`
 	LiveLosingBeDang float64 `json:"liveLosingBeDang" gorm:"default:0"`
 	SlotLosingBeDang float64 `json:"slotLosingBeDang" gorm:"default:0"`
@@ -0,0 +1,35 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/hotbrainy/go-betting/backend/internal/models"
+	"github.com/hotbrainy/go-betting/backend/internal/redis"
+	"gorm.io/gorm"
+)
+
+// CreateAlert stores an admin alert and publishes it to the admins connected for real-time
+// updates
+func CreateAlert(db *gorm.DB, alertType, title, message string, entityID uint, redirectURL string) (*models.Alert, error) {
+	alert := models.Alert{
+		Type:        alertType,
+		Title:       title,
+		Message:     message,
+		EntityID:    entityID,
+		IsRead:      false,
+		RedirectURL: redirectURL,
+	}
+
+	if err := db.Create(&alert).Error; err != nil {
+		return nil, err
+	}
+
+	// Publish alert to Redis for real-time updates
+	if redis.Client != nil {
+		alertJSON, _ := json.Marshal(alert)
+		redis.Client.Publish(context.Background(), "alerts:admin", alertJSON)
+	}
+
+	return &alert, nil
+}
@@ -0,0 +1,89 @@
+package services
+
+import (
+	"gorm.io/gorm"
+)
+
+// bettingStatsColumns are the user statistics casino ingest and mini game settlement accrue
+var bettingStatsColumns = []string{
+	"live_betting", "live_winning",
+	"slot_betting", "slot_jackpot",
+	"holdem_betting", "holdem_winning",
+	"mini_danpol_betting", "mini_danpol_winner",
+	"mini_combination_betting", "mini_combination_winnings",
+}
+
+// rebuildCasinoStatsSQL sums casino bets and wins by user and category as AccrueCasinoPlay does,
+// taking the game type from the game name of bets recorded before it had a column
+const rebuildCasinoStatsSQL = `UPDATE users SET
+	live_betting = s.live_betting, live_winning = s.live_winning,
+	slot_betting = s.slot_betting, slot_jackpot = s.slot_jackpot,
+	holdem_betting = s.holdem_betting, holdem_winning = s.holdem_winning
+FROM (
+	SELECT user_id,
+		COALESCE(SUM(CASE WHEN type = 'bet' AND category = 'live' THEN ABS(amount) END), 0) AS live_betting,
+		COALESCE(SUM(CASE WHEN type = 'win' AND category = 'live' THEN amount END), 0) AS live_winning,
+		COALESCE(SUM(CASE WHEN type = 'bet' AND category = 'slot' THEN ABS(amount) END), 0) AS slot_betting,
+		COALESCE(SUM(CASE WHEN type = 'win' AND category = 'slot' THEN amount END), 0) AS slot_jackpot,
+		COALESCE(SUM(CASE WHEN type = 'bet' AND category = 'holdem' THEN ABS(amount) END), 0) AS holdem_betting,
+		COALESCE(SUM(CASE WHEN type = 'win' AND category = 'holdem' THEN amount END), 0) AS holdem_winning
+	FROM (
+		SELECT user_id, type, amount,
+			CASE
+				WHEN game LIKE '%slot%' THEN 'slot'
+				WHEN game LIKE '%holdem%' OR game LIKE '%poker%' THEN 'holdem'
+				ELSE 'live'
+			END AS category
+		FROM (
+			SELECT user_id, type, amount,
+				LOWER(COALESCE(NULLIF(game_type, ''), SPLIT_PART(game_name, '|', 2))) AS game
+			FROM casino_bets
+			WHERE type IN ('bet', 'win') AND status <> 'rollback' AND deleted_at IS NULL
+		) games
+	) bets
+	GROUP BY user_id
+) s
+WHERE users.id = s.user_id`
+
+// rebuildMiniStatsSQL sums settled mini game bets and their payouts by user as
+// AccrueMiniBetSettlement does
+const rebuildMiniStatsSQL = `UPDATE users SET
+	mini_danpol_betting = s.danpol_betting, mini_danpol_winner = s.danpol_winner,
+	mini_combination_betting = s.combination_betting, mini_combination_winnings = s.combination_winnings
+FROM (
+	SELECT user_id,
+		COALESCE(SUM(CASE WHEN COALESCE(bet_type, '') <> 'combination' THEN amount END), 0) AS danpol_betting,
+		COALESCE(SUM(CASE WHEN COALESCE(bet_type, '') <> 'combination' AND result IN ('win', 'won') THEN amount * odds END), 0) AS danpol_winner,
+		COALESCE(SUM(CASE WHEN bet_type = 'combination' THEN amount END), 0) AS combination_betting,
+		COALESCE(SUM(CASE WHEN bet_type = 'combination' AND result IN ('win', 'won') THEN amount * odds END), 0) AS combination_winnings
+	FROM powerball_histories
+	WHERE status = 'done' AND deleted_at IS NULL
+	GROUP BY user_id
+) s
+WHERE users.id = s.user_id`
+
+// RebuildBettingStats recomputes every user's casino and mini game betting and winning
+// statistics from casino_bets and powerball_histories. The statistics are accrued as play is
+// recorded; this fills in the history from before they were, and may be run again since it
+// replaces the totals. The users table is locked against writes meanwhile, so play recorded
+// concurrently is counted exactly once.
+func RebuildBettingStats(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("LOCK TABLE users IN SHARE ROW EXCLUSIVE MODE").Error; err != nil {
+			return err
+		}
+
+		reset := map[string]interface{}{}
+		for _, column := range bettingStatsColumns {
+			reset[column] = 0
+		}
+		if err := tx.Table("users").Where("1 = 1").Updates(reset).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Exec(rebuildCasinoStatsSQL).Error; err != nil {
+			return err
+		}
+		return tx.Exec(rebuildMiniStatsSQL).Error
+	})
+}
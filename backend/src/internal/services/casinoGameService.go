@@ -0,0 +1,274 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hotbrainy/go-betting/backend/internal/casino"
+	"github.com/hotbrainy/go-betting/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+var ErrCasinoGameNotFound = errors.New("casino game not found")
+
+// CasinoGameLobbyOrder lists featured games first, then by sort order and title
+const CasinoGameLobbyOrder = "featured DESC, sort_order ASC, title ASC, id ASC"
+
+// CasinoGameFilter narrows a catalog listing. Visible listings only show the enabled games
+// still offered by their provider.
+type CasinoGameFilter struct {
+	Vendor       string
+	Type         string
+	Tag          string
+	Search       string
+	FeaturedOnly bool
+	Visible      bool
+}
+
+// CasinoVendorKey normalizes a vendor name the way lobby game names are matched
+func CasinoVendorKey(vendor string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(vendor), " ", ""))
+}
+
+// NormalizeCasinoGameTags joins tags into the stored comma separated form, lower cased and
+// without duplicates
+func NormalizeCasinoGameTags(tags []string) string {
+	seen := map[string]bool{}
+	var normalized []string
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(strings.ReplaceAll(tag, ",", " ")))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	return strings.Join(normalized, ",")
+}
+
+// CasinoGameQuery returns the catalog query of filter
+func CasinoGameQuery(db *gorm.DB, filter CasinoGameFilter) *gorm.DB {
+	query := db.Model(&models.CasinoGame{})
+	if filter.Visible {
+		query = query.Where("enabled = ? AND available = ?", true, true)
+	}
+	if filter.Vendor != "" {
+		query = query.Where("vendor = ?", CasinoVendorKey(filter.Vendor))
+	}
+	if filter.Type != "" {
+		query = query.Where("type = ?", filter.Type)
+	}
+	if filter.Tag != "" {
+		query = query.Where("? = ANY(string_to_array(tags, ','))", strings.ToLower(filter.Tag))
+	}
+	if filter.Search != "" {
+		query = query.Where("title ILIKE ?", "%"+filter.Search+"%")
+	}
+	if filter.FeaturedOnly {
+		query = query.Where("featured = ?", true)
+	}
+	return query
+}
+
+// SyncCasinoGames refreshes the catalog games of vendor and gameType from provider's game
+// list. Curation fields are kept; games missing from the list are marked unavailable.
+func SyncCasinoGames(ctx context.Context, db *gorm.DB, provider casino.Provider, vendor, gameType string) (int, error) {
+	vendor = CasinoVendorKey(vendor)
+	if vendor == "" {
+		return 0, errors.New("vendor is required to sync casino games")
+	}
+
+	games, err := provider.Games(ctx, vendor, gameType)
+	if err != nil {
+		return 0, err
+	}
+
+	syncedAt := time.Now()
+	synced := 0
+	for _, g := range games {
+		if g.ID == "" {
+			continue
+		}
+		if g.Type == "" {
+			g.Type = gameType
+		}
+
+		var game models.CasinoGame
+		err := db.Where("provider = ? AND vendor = ? AND game_id = ?", provider.Name(), vendor, g.ID).
+			First(&game).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			game = models.CasinoGame{
+				Provider:  provider.Name(),
+				Vendor:    vendor,
+				GameID:    g.ID,
+				Type:      g.Type,
+				Title:     g.Title,
+				Thumbnail: g.Thumbnail,
+				Enabled:   true,
+				Available: true,
+				SyncedAt:  syncedAt,
+			}
+			err = db.Create(&game).Error
+		} else if err == nil {
+			err = db.Model(&game).Updates(map[string]interface{}{
+				"type":      g.Type,
+				"title":     g.Title,
+				"thumbnail": g.Thumbnail,
+				"available": true,
+				"synced_at": syncedAt,
+			}).Error
+		}
+		if err != nil {
+			return synced, err
+		}
+		synced++
+	}
+
+	// Games the provider no longer lists
+	stale := db.Model(&models.CasinoGame{}).
+		Where("provider = ? AND vendor = ? AND synced_at < ?", provider.Name(), vendor, syncedAt)
+	if gameType != "" {
+		stale = stale.Where("type = ?", gameType)
+	}
+	if err := stale.Update("available", false).Error; err != nil {
+		return synced, err
+	}
+	return synced, nil
+}
+
+// SyncCasinoCatalog refreshes every vendor and game type of the enabled game API rows and of
+// the catalog itself
+func SyncCasinoCatalog(ctx context.Context, db *gorm.DB) (int, error) {
+	type target struct {
+		Vendor string
+		Type   string
+	}
+
+	var apis []models.GameAPI
+	if err := db.Where("whether_to_use = ? AND game_company_name <> ''", true).Find(&apis).Error; err != nil {
+		return 0, err
+	}
+	var catalog []target
+	if err := db.Model(&models.CasinoGame{}).Distinct("vendor", "type").Scan(&catalog).Error; err != nil {
+		return 0, err
+	}
+
+	seen := map[target]bool{}
+	var targets []target
+	add := func(t target) {
+		t.Vendor = CasinoVendorKey(t.Vendor)
+		if t.Vendor != "" && !seen[t] {
+			seen[t] = true
+			targets = append(targets, t)
+		}
+	}
+	for _, api := range apis {
+		add(target{Vendor: api.GameCompanyName, Type: api.GameType})
+	}
+	for _, t := range catalog {
+		add(t)
+	}
+	sort.Slice(targets, func(i, j int) bool {
+		if targets[i].Vendor != targets[j].Vendor {
+			return targets[i].Vendor < targets[j].Vendor
+		}
+		return targets[i].Type < targets[j].Type
+	})
+
+	synced := 0
+	var errs []error
+	for _, t := range targets {
+		provider, err := casino.ForVendor(db, t.Vendor)
+		if errors.Is(err, casino.ErrVendorDisabled) {
+			continue
+		}
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		n, err := SyncCasinoGames(ctx, db, provider, t.Vendor, t.Type)
+		synced += n
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s/%s: %w", t.Vendor, t.Type, err))
+		}
+	}
+	return synced, errors.Join(errs...)
+}
+
+func findVisibleCasinoGame(db *gorm.DB, gameID uint) (*models.CasinoGame, error) {
+	var game models.CasinoGame
+	if err := db.Where("id = ? AND enabled = ? AND available = ?", gameID, true, true).
+		First(&game).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCasinoGameNotFound
+		}
+		return nil, err
+	}
+	return &game, nil
+}
+
+// AddCasinoGameFavorite stars a game for the user; starring it again changes nothing
+func AddCasinoGameFavorite(db *gorm.DB, userID, gameID uint) (*models.CasinoGameFavorite, error) {
+	if _, err := findVisibleCasinoGame(db, gameID); err != nil {
+		return nil, err
+	}
+
+	var favorite models.CasinoGameFavorite
+	err := db.Where("user_id = ? AND casino_game_id = ?", userID, gameID).First(&favorite).Error
+	if err == nil {
+		return &favorite, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	favorite = models.CasinoGameFavorite{UserID: userID, CasinoGameID: gameID}
+	if err := db.Create(&favorite).Error; err != nil {
+		return nil, err
+	}
+	return &favorite, nil
+}
+
+// RemoveCasinoGameFavorite unstars a game for the user
+func RemoveCasinoGameFavorite(db *gorm.DB, userID, gameID uint) error {
+	return db.Where("user_id = ? AND casino_game_id = ?", userID, gameID).
+		Delete(&models.CasinoGameFavorite{}).Error
+}
+
+// RecordCasinoGamePlay notes that the user launched a catalog game. Games missing from the
+// catalog are not recorded.
+func RecordCasinoGamePlay(db *gorm.DB, userID uint, provider, vendor, gameID string) error {
+	var game models.CasinoGame
+	err := db.Where("provider = ? AND vendor = ? AND game_id = ?", provider, CasinoVendorKey(vendor), gameID).
+		First(&game).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	var play models.CasinoGamePlay
+	err = db.Where("user_id = ? AND casino_game_id = ?", userID, game.ID).First(&play).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return db.Create(&models.CasinoGamePlay{
+			UserID:       userID,
+			CasinoGameID: game.ID,
+			PlayCount:    1,
+			PlayedAt:     now,
+		}).Error
+	}
+	if err != nil {
+		return err
+	}
+	return db.Model(&play).Updates(map[string]interface{}{
+		"play_count": gorm.Expr("play_count + 1"),
+		"played_at":  now,
+	}).Error
+}
@@ -0,0 +1,262 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/hotbrainy/go-betting/backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Casino betting limits
+const (
+	CasinoLimitMaxBet        = "maxBet"
+	CasinoLimitDailyTurnover = "dailyTurnover"
+	CasinoLimitDailyLoss     = "dailyLoss"
+)
+
+var ErrCasinoLimit = errors.New("casino betting limit reached")
+
+// CasinoLimitError is a casino limit of a user that play reached: Value is what the stakes,
+// turnover or loss would come to, over Cap
+type CasinoLimitError struct {
+	UserID uint
+	Kind   string
+	Cap    float64
+	Value  float64
+}
+
+func (e *CasinoLimitError) Error() string {
+	return fmt.Sprintf("%s: %s %.2f over %.2f", ErrCasinoLimit, e.Kind, e.Value, e.Cap)
+}
+
+func (e *CasinoLimitError) Unwrap() error {
+	return ErrCasinoLimit
+}
+
+// CasinoLimits are the casino betting caps of a user, 0 for no limit
+type CasinoLimits struct {
+	MaxBet        float64 `json:"maxBet"` // Stakes per round
+	DailyTurnover float64 `json:"dailyTurnover"`
+	DailyLoss     float64 `json:"dailyLoss"`
+}
+
+func (l CasinoLimits) none() bool {
+	return l.MaxBet <= 0 && l.DailyTurnover <= 0 && l.DailyLoss <= 0
+}
+
+// CasinoLimitsOf returns the casino limits of the user: their own where set, their level's
+// otherwise
+func CasinoLimitsOf(db *gorm.DB, userID uint) (CasinoLimits, error) {
+	var user models.User
+	if err := db.First(&user, userID).Error; err != nil {
+		return CasinoLimits{}, err
+	}
+	limits := CasinoLimits{
+		MaxBet:        user.CasinoMaxBet,
+		DailyTurnover: user.CasinoDailyTurnoverLimit,
+		DailyLoss:     user.CasinoDailyLossLimit,
+	}
+
+	var profile models.Profile
+	if err := db.Where("user_id = ?", userID).First(&profile).Error; err != nil {
+		return limits, err
+	}
+	var level models.Level
+	if err := db.Where("level_number = ?", profile.Level).First(&level).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return limits, nil
+		}
+		return limits, err
+	}
+
+	if limits.MaxBet <= 0 {
+		limits.MaxBet = level.CasinoMaxBet
+	}
+	if limits.DailyTurnover <= 0 {
+		limits.DailyTurnover = level.CasinoDailyTurnoverLimit
+	}
+	if limits.DailyLoss <= 0 {
+		limits.DailyLoss = level.CasinoDailyLossLimit
+	}
+	return limits, nil
+}
+
+// CasinoDailyUsage returns the user's casino stakes and net loss of the day of now, leaving out
+// rolled back transactions
+func CasinoDailyUsage(db *gorm.DB, userID uint, now time.Time) (turnover, loss float64, err error) {
+	year, month, day := now.Date()
+	dayStart := time.Date(year, month, day, 0, 0, 0, 0, now.Location())
+
+	var usage struct {
+		Turnover float64
+		Net      float64
+	}
+	err = db.Model(&models.CasinoBet{}).
+		Select("COALESCE(SUM(CASE WHEN type = 'bet' THEN -amount ELSE 0 END), 0) AS turnover, COALESCE(SUM(amount), 0) AS net").
+		Where("user_id = ? AND type IN ? AND status <> ? AND created_at >= ?", userID, []string{"bet", "win"}, "rollback", dayStart).
+		Scan(&usage).Error
+	return usage.Turnover, -usage.Net, err
+}
+
+// checkCasinoDailyLimits checks the day's usage plus extra against the daily limits
+func checkCasinoDailyLimits(userID uint, limits CasinoLimits, turnover, loss, extra float64) *CasinoLimitError {
+	if limits.DailyTurnover > 0 && turnover+extra > limits.DailyTurnover {
+		return &CasinoLimitError{UserID: userID, Kind: CasinoLimitDailyTurnover, Cap: limits.DailyTurnover, Value: turnover + extra}
+	}
+	if limits.DailyLoss > 0 && loss+extra > limits.DailyLoss {
+		return &CasinoLimitError{UserID: userID, Kind: CasinoLimitDailyLoss, Cap: limits.DailyLoss, Value: loss + extra}
+	}
+	return nil
+}
+
+// CheckCasinoStake refuses a stake on a round of provider that would take the user over their
+// max bet for the round, or over their daily turnover or loss if the stake is lost
+func CheckCasinoStake(db *gorm.DB, userID uint, provider, roundID string, stake float64) error {
+	limits, err := CasinoLimitsOf(db, userID)
+	if err != nil || limits.none() {
+		return err
+	}
+
+	if limits.MaxBet > 0 {
+		var staked float64
+		if roundID != "" {
+			if err := db.Model(&models.CasinoWalletTransaction{}).Select("COALESCE(SUM(amount), 0)").
+				Where("user_id = ? AND provider = ? AND round_id = ? AND action = ? AND rolled_back = ?", userID, provider, roundID, "debit", false).
+				Scan(&staked).Error; err != nil {
+				return err
+			}
+		}
+		if staked+stake > limits.MaxBet {
+			return &CasinoLimitError{UserID: userID, Kind: CasinoLimitMaxBet, Cap: limits.MaxBet, Value: staked + stake}
+		}
+	}
+
+	turnover, loss, err := CasinoDailyUsage(db, userID, time.Now())
+	if err != nil {
+		return err
+	}
+	if breach := checkCasinoDailyLimits(userID, limits, turnover, loss, stake); breach != nil {
+		return breach
+	}
+	return nil
+}
+
+// CapCasinoTransfer caps amount moved to a casino account holding casinoBalance so that losing
+// all of it keeps the user within their daily turnover and loss. It fails when nothing more can
+// be moved today. Max bet cannot be enforced on transfers.
+func CapCasinoTransfer(db *gorm.DB, userID uint, casinoBalance, amount float64) (float64, error) {
+	limits, err := CasinoLimitsOf(db, userID)
+	if err != nil || limits.none() {
+		return amount, err
+	}
+
+	turnover, loss, err := CasinoDailyUsage(db, userID, time.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	allowance := math.Inf(1)
+	if limits.DailyTurnover > 0 {
+		allowance = math.Min(allowance, limits.DailyTurnover-turnover-casinoBalance)
+	}
+	if limits.DailyLoss > 0 {
+		allowance = math.Min(allowance, limits.DailyLoss-loss-casinoBalance)
+	}
+	if amount <= allowance {
+		return amount, nil
+	}
+	if allowance <= 0 {
+		if breach := checkCasinoDailyLimits(userID, limits, turnover, loss, casinoBalance+amount); breach != nil {
+			return 0, breach
+		}
+		return 0, ErrCasinoLimit
+	}
+	return allowance, nil
+}
+
+// CasinoLimitBreaches checks the user's casino play of today after the fact, for play the
+// provider settles without asking us first. roundStake is the stake of the round just played.
+func CasinoLimitBreaches(db *gorm.DB, userID uint, roundStake float64) ([]*CasinoLimitError, error) {
+	limits, err := CasinoLimitsOf(db, userID)
+	if err != nil || limits.none() {
+		return nil, err
+	}
+
+	var breaches []*CasinoLimitError
+	if limits.MaxBet > 0 && roundStake > limits.MaxBet {
+		breaches = append(breaches, &CasinoLimitError{UserID: userID, Kind: CasinoLimitMaxBet, Cap: limits.MaxBet, Value: roundStake})
+	}
+
+	turnover, loss, err := CasinoDailyUsage(db, userID, time.Now())
+	if err != nil {
+		return breaches, err
+	}
+	if limits.DailyTurnover > 0 && turnover > limits.DailyTurnover {
+		breaches = append(breaches, &CasinoLimitError{UserID: userID, Kind: CasinoLimitDailyTurnover, Cap: limits.DailyTurnover, Value: turnover})
+	}
+	if limits.DailyLoss > 0 && loss > limits.DailyLoss {
+		breaches = append(breaches, &CasinoLimitError{UserID: userID, Kind: CasinoLimitDailyLoss, Cap: limits.DailyLoss, Value: loss})
+	}
+	return breaches, nil
+}
+
+// RecordCasinoLimitBreach stores breach for today, reporting whether it is the user's first
+// breach of that limit today, the one admins are alerted about
+func RecordCasinoLimitBreach(db *gorm.DB, breach *CasinoLimitError, action string) (bool, error) {
+	record := models.CasinoLimitBreach{
+		UserID: breach.UserID,
+		Kind:   breach.Kind,
+		Day:    time.Now().Format("2006-01-02"),
+		Cap:    breach.Cap,
+		Value:  breach.Value,
+		Action: action,
+		Count:  1,
+	}
+	result := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&record)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected > 0 {
+		return true, nil
+	}
+
+	return false, db.Model(&models.CasinoLimitBreach{}).
+		Where("user_id = ? AND kind = ? AND day = ?", record.UserID, record.Kind, record.Day).
+		Updates(map[string]interface{}{
+			"count":  gorm.Expr("count + 1"),
+			"cap":    record.Cap,
+			"value":  record.Value,
+			"action": action,
+		}).Error
+}
+
+// AlertCasinoLimitBreach records breach and alerts admins the first time the user hits that
+// limit on a day. action is "rejected" for play refused, "exceeded" for play found over the
+// limit after the fact.
+func AlertCasinoLimitBreach(db *gorm.DB, breach *CasinoLimitError, action string) {
+	first, err := RecordCasinoLimitBreach(db, breach, action)
+	if err != nil {
+		fmt.Printf("Error recording casino limit breach: %v\n", err)
+		return
+	}
+	if !first {
+		return
+	}
+
+	var user models.User
+	if err := db.Select("id", "userid").First(&user, breach.UserID).Error; err != nil {
+		fmt.Printf("Error finding user %d for casino limit alert: %v\n", breach.UserID, err)
+		return
+	}
+
+	title := "Casino Limit Reached"
+	message := fmt.Sprintf("User %s (ID: %d) %s casino %s limit: %.2f over %.2f",
+		user.Userid, user.ID, action, breach.Kind, breach.Value, breach.Cap)
+	if _, err := CreateAlert(db, "casinoLimit", title, message, user.ID, "/admin/casino/limits"); err != nil {
+		fmt.Printf("Error creating casino limit alert: %v\n", err)
+	}
+}
@@ -0,0 +1,161 @@
+package services
+
+import (
+	"errors"
+
+	"github.com/hotbrainy/go-betting/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+var ErrCasinoReportGroup = errors.New("unknown casino report grouping")
+
+// casinoGGRColumns sums the stakes (stored negative), wins and their difference, the gross
+// gaming revenue, of casino bet rows
+const casinoGGRColumns = `COALESCE(SUM(CASE WHEN casino_bets.type = 'bet' THEN -casino_bets.amount ELSE 0 END), 0) AS bet_amount,
+	COALESCE(SUM(CASE WHEN casino_bets.type = 'win' THEN casino_bets.amount ELSE 0 END), 0) AS win_amount,
+	-COALESCE(SUM(casino_bets.amount), 0) AS ggr,
+	COUNT(CASE WHEN casino_bets.type = 'bet' THEN 1 END) AS bet_count`
+
+// CasinoReportFilter narrows the casino bets a report covers
+type CasinoReportFilter struct {
+	DateFrom string
+	DateTo   string
+	Vendor   string
+	GameCode string
+	GameType string
+	Day      string // 2006-01-02
+	UserID   uint
+}
+
+// CasinoReportQuery returns the casino bets and wins of filter that count towards GGR, rolled
+// back ones left out
+func CasinoReportQuery(db *gorm.DB, filter CasinoReportFilter) *gorm.DB {
+	return CasinoBetQuery(db, filter).
+		Where("casino_bets.type IN ? AND casino_bets.status <> ?", []string{"bet", "win"}, "rollback")
+}
+
+// CasinoBetQuery returns the casino bet rows of filter, whatever their type and status. Dates
+// are matched against the play time.
+func CasinoBetQuery(db *gorm.DB, filter CasinoReportFilter) *gorm.DB {
+	query := db.Model(&models.CasinoBet{})
+
+	if filter.DateFrom != "" {
+		query = query.Where("casino_bets.played_at >= ?", filter.DateFrom)
+	}
+	if filter.DateTo != "" {
+		query = query.Where("casino_bets.played_at <= ?", filter.DateTo)
+	}
+	if filter.Day != "" {
+		query = query.Where("casino_bets.played_at >= CAST(? AS date) AND casino_bets.played_at < CAST(? AS date) + 1", filter.Day, filter.Day)
+	}
+	if filter.Vendor != "" {
+		query = query.Where("casino_bets.vendor = ?", filter.Vendor)
+	}
+	if filter.GameCode != "" {
+		query = query.Where("casino_bets.game_code = ?", filter.GameCode)
+	}
+	if filter.GameType != "" {
+		query = query.Where("casino_bets.game_type = ?", filter.GameType)
+	}
+	if filter.UserID != 0 {
+		query = query.Where("casino_bets.user_id = ?", filter.UserID)
+	}
+	return query
+}
+
+// CasinoGGRRow is a line of a casino GGR report; the key columns not grouped on are empty
+type CasinoGGRRow struct {
+	Vendor    string  `json:"vendor,omitempty"`
+	GameCode  string  `json:"gameCode,omitempty"`
+	GameTitle string  `json:"gameTitle,omitempty"`
+	Day       string  `json:"day,omitempty"`
+	BetAmount float64 `json:"betAmount"`
+	WinAmount float64 `json:"winAmount"`
+	GGR       float64 `json:"ggr" gorm:"column:ggr"`
+	BetCount  int64   `json:"betCount"`
+	Players   int64   `json:"players"`
+}
+
+// CasinoGGRReport sums the GGR of filter by "vendor", "game" or "day", highest GGR first and
+// days newest first
+func CasinoGGRReport(db *gorm.DB, filter CasinoReportFilter, groupBy string) ([]CasinoGGRRow, error) {
+	query := CasinoReportQuery(db, filter)
+	columns := casinoGGRColumns + ", COUNT(DISTINCT casino_bets.user_id) AS players"
+
+	switch groupBy {
+	case "vendor":
+		query = query.Select("casino_bets.vendor, " + columns).
+			Group("casino_bets.vendor").
+			Order("ggr DESC")
+	case "game":
+		query = query.Select("casino_bets.vendor, casino_bets.game_code, MAX(casino_bets.game_title) AS game_title, " + columns).
+			Group("casino_bets.vendor, casino_bets.game_code").
+			Order("ggr DESC")
+	case "day":
+		query = query.Select("TO_CHAR(DATE(casino_bets.played_at), 'YYYY-MM-DD') AS day, " + columns).
+			Group("DATE(casino_bets.played_at)").
+			Order("DATE(casino_bets.played_at) DESC")
+	default:
+		return nil, ErrCasinoReportGroup
+	}
+
+	var rows []CasinoGGRRow
+	err := query.Scan(&rows).Error
+	return rows, err
+}
+
+// CasinoUserGGRRow is the GGR a user brought in; negative GGR is the user's net win
+type CasinoUserGGRRow struct {
+	UserID    uint    `json:"userId"`
+	Userid    string  `json:"userid"`
+	Nickname  string  `json:"nickname"`
+	BetAmount float64 `json:"betAmount"`
+	WinAmount float64 `json:"winAmount"`
+	GGR       float64 `json:"ggr" gorm:"column:ggr"`
+	BetCount  int64   `json:"betCount"`
+}
+
+// casinoUserGGRQuery groups the GGR of filter by user
+func casinoUserGGRQuery(db *gorm.DB, filter CasinoReportFilter) *gorm.DB {
+	return CasinoReportQuery(db, filter).
+		Select("casino_bets.user_id, users.userid, profiles.nickname, " + casinoGGRColumns).
+		Joins("JOIN users ON users.id = casino_bets.user_id").
+		Joins("LEFT JOIN profiles ON profiles.user_id = casino_bets.user_id").
+		Group("casino_bets.user_id, users.userid, profiles.nickname")
+}
+
+// CasinoUserGGR pages through the GGR of filter by user, highest GGR first
+func CasinoUserGGR(db *gorm.DB, filter CasinoReportFilter, offset, limit int) ([]CasinoUserGGRRow, int64, error) {
+	var total int64
+	if err := CasinoReportQuery(db, filter).
+		Distinct("casino_bets.user_id").
+		Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var rows []CasinoUserGGRRow
+	err := casinoUserGGRQuery(db, filter).
+		Order("ggr DESC").
+		Offset(offset).
+		Limit(limit).
+		Scan(&rows).Error
+	return rows, total, err
+}
+
+// CasinoTopPlayers returns the limit users who won the most from the casino, the lowest GGR,
+// and who lost the most, the highest GGR
+func CasinoTopPlayers(db *gorm.DB, filter CasinoReportFilter, limit int) (winners, losers []CasinoUserGGRRow, err error) {
+	if err = casinoUserGGRQuery(db, filter).
+		Having("-COALESCE(SUM(casino_bets.amount), 0) < 0").
+		Order("ggr ASC").
+		Limit(limit).
+		Scan(&winners).Error; err != nil {
+		return nil, nil, err
+	}
+	err = casinoUserGGRQuery(db, filter).
+		Having("-COALESCE(SUM(casino_bets.amount), 0) > 0").
+		Order("ggr DESC").
+		Limit(limit).
+		Scan(&losers).Error
+	return winners, losers, err
+}
@@ -0,0 +1,145 @@
+package services
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	"github.com/hotbrainy/go-betting/backend/internal/casino"
+	"github.com/hotbrainy/go-betting/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// Casino game categories, each with its own rolling rates and statistics
+const (
+	CasinoCategoryLive   = "live"
+	CasinoCategorySlot   = "slot"
+	CasinoCategoryHoldem = "holdem"
+)
+
+// CasinoGameCategory classifies a provider game type; anything that is not a slot or a
+// hold'em/poker table is live casino
+func CasinoGameCategory(gameType string) string {
+	gameType = strings.ToLower(gameType)
+	switch {
+	case strings.Contains(gameType, "slot"):
+		return CasinoCategorySlot
+	case strings.Contains(gameType, "holdem"), strings.Contains(gameType, "poker"):
+		return CasinoCategoryHoldem
+	default:
+		return CasinoCategoryLive
+	}
+}
+
+// casinoRollingRate returns the rolling rate (%) of category for user, falling back to the
+// level rate when the user has none configured, clamped to the level's minimum and maximum
+// where those are set
+func casinoRollingRate(user models.User, level *models.Level, category string) float64 {
+	var rate, levelRate, minRate, maxRate float64
+	switch category {
+	case CasinoCategorySlot:
+		rate = user.Slot
+		if level != nil {
+			levelRate, minRate, maxRate = level.SlotRollingRate, level.CasinoSlotsMinimumRolling, level.CasinoSlotsMaxRolling
+		}
+	case CasinoCategoryHoldem:
+		rate = user.Hold
+		if level != nil {
+			levelRate, minRate, maxRate = level.HoldemRollingRate, level.HoldemPokerMinimumRolling, level.HoldemPokerMaximumRolling
+		}
+	default:
+		rate = user.Live
+		if level != nil {
+			levelRate, minRate, maxRate = level.LiveRollingRate, level.CasinoLiveMinimumRolling, level.CasinoLiveMaximumRolling
+		}
+	}
+
+	if rate <= 0 {
+		rate = levelRate
+	}
+	if minRate > 0 && rate < minRate {
+		rate = minRate
+	}
+	if maxRate > 0 && rate > maxRate {
+		rate = maxRate
+	}
+	return rate
+}
+
+// updateCasinoStats adds betting and winning to the user's statistics of category
+func updateCasinoStats(tx *gorm.DB, userID uint, category string, betting, winning float64) error {
+	bettingColumn, winningColumn := "live_betting", "live_winning"
+	switch category {
+	case CasinoCategorySlot:
+		bettingColumn, winningColumn = "slot_betting", "slot_jackpot"
+	case CasinoCategoryHoldem:
+		bettingColumn, winningColumn = "holdem_betting", "holdem_winning"
+	}
+
+	stats := map[string]interface{}{}
+	if betting != 0 {
+		stats[bettingColumn] = gorm.Expr(bettingColumn+" + ?", betting)
+	}
+	if winning != 0 {
+		stats[winningColumn] = gorm.Expr(winningColumn+" + ?", winning)
+	}
+	if len(stats) == 0 {
+		return nil
+	}
+	return tx.Model(&models.User{}).Where("id = ?", userID).Updates(stats).Error
+}
+
+// AccrueCasinoPlay books the statistics of a casino bet or win of the user in the category of
+// its game and, for bets, the rolling on the stake at the user's rate for that category.
+// Bets carry a negative amount, as in CasinoBet.
+func AccrueCasinoPlay(tx *gorm.DB, userID uint, kind string, game casino.GameRef, amount float64, transactionID string, at time.Time) error {
+	category := CasinoGameCategory(game.Type)
+
+	switch kind {
+	case "bet":
+		if err := updateCasinoStats(tx, userID, category, math.Abs(amount), 0); err != nil {
+			return err
+		}
+	case "win":
+		return updateCasinoStats(tx, userID, category, 0, amount)
+	default:
+		return nil
+	}
+
+	var user models.User
+	if err := tx.First(&user, userID).Error; err != nil {
+		return err
+	}
+
+	var profile models.Profile
+	if err := tx.Where("user_id = ?", userID).First(&profile).Error; err != nil {
+		return err
+	}
+
+	var level *models.Level
+	var levelSettings models.Level
+	if err := tx.Where("level_number = ?", profile.Level).First(&levelSettings).Error; err == nil {
+		level = &levelSettings
+	}
+
+	rolling := math.Abs(amount) * casinoRollingRate(user, level, category) / 100
+	if rolling <= 0 {
+		return nil
+	}
+
+	rollAfter := profile.Roll + rolling
+	if err := tx.Model(&profile).Update("roll", rollAfter).Error; err != nil {
+		return err
+	}
+	return tx.Create(&models.Transaction{
+		UserID:        userID,
+		Amount:        rolling,
+		Type:          "Rolling",
+		Shortcut:      game.Vendor + "|" + game.Type,
+		Explation:     transactionID + "_rolling",
+		BalanceBefore: profile.Roll,
+		BalanceAfter:  rollAfter,
+		Status:        "success",
+		TransactionAt: at,
+	}).Error
+}
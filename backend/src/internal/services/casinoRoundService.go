@@ -0,0 +1,290 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hotbrainy/go-betting/backend/internal/casino"
+	"github.com/hotbrainy/go-betting/backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// casinoRoundLinkWindow bounds how far back a win without a round ID looks for its bet
+const casinoRoundLinkWindow = 24 * time.Hour
+
+// CasinoBetGame reads the game of a casino bet from its Details, stored as
+// {"game": {vendor, type, id, title, round}}
+func CasinoBetGame(details interface{}) casino.GameRef {
+	var raw []byte
+	switch v := details.(type) {
+	case nil:
+		return casino.GameRef{}
+	case []byte:
+		raw = v
+	case string:
+		raw = []byte(v)
+	default:
+		var err error
+		if raw, err = json.Marshal(v); err != nil {
+			return casino.GameRef{}
+		}
+	}
+
+	var parsed struct {
+		Game casino.GameRef `json:"game"`
+	}
+	if err := json.Unmarshal(raw, &parsed); err != nil {
+		return casino.GameRef{}
+	}
+	return parsed.Game
+}
+
+// CasinoGameTypeSlot is the game type of slots on casino bets and rounds
+const CasinoGameTypeSlot = "slot"
+
+// SetCasinoBetGame stores game in the Details of bet and in its searchable game columns
+func SetCasinoBetGame(bet *models.CasinoBet, game casino.GameRef) {
+	bet.Details = map[string]interface{}{"game": game}
+	bet.Vendor = game.Vendor
+	bet.GameType = game.Type
+	bet.GameCode = game.ID
+	bet.GameTitle = game.Title
+	bet.RoundID = game.Round
+}
+
+// BackfillCasinoBetGames fills the game columns of the casino bets ingested before they
+// existed from their Details
+func BackfillCasinoBetGames(db *gorm.DB) (int64, error) {
+	result := db.Exec(`UPDATE casino_bets SET
+			vendor = COALESCE(details->'game'->>'vendor', ''),
+			game_type = COALESCE(details->'game'->>'type', ''),
+			game_code = COALESCE(details->'game'->>'id', ''),
+			game_title = COALESCE(details->'game'->>'title', ''),
+			round_id = COALESCE(details->'game'->>'round', '')
+		WHERE (vendor IS NULL OR vendor = '') AND jsonb_typeof(details->'game') = 'object'`)
+	return result.RowsAffected, result.Error
+}
+
+// RecordCasinoRound links an ingested casino bet or win to the round of its game and refreshes
+// the round. Wins without a round ID join the user's latest pending round of the same game.
+func RecordCasinoRound(tx *gorm.DB, provider string, bet *models.CasinoBet) (*models.CasinoRound, error) {
+	game := CasinoBetGame(bet.Details)
+
+	var round models.CasinoRound
+	found := false
+	if game.Round == "" && bet.Type == "win" {
+		err := tx.Where("user_id = ? AND provider = ? AND vendor = ? AND game_id = ? AND result_status = ? AND created_at >= ?",
+			bet.UserID, provider, game.Vendor, game.ID, "pending", bet.CreatedAt.Add(-casinoRoundLinkWindow)).
+			Order("id DESC").First(&round).Error
+		if err == nil {
+			found = true
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+	}
+
+	if !found {
+		roundID := game.Round
+		if roundID == "" {
+			roundID = "tx:" + bet.TransID
+		}
+
+		round = models.CasinoRound{
+			UserID:       bet.UserID,
+			Provider:     provider,
+			Vendor:       game.Vendor,
+			RoundID:      roundID,
+			GameType:     game.Type,
+			GameID:       game.ID,
+			GameTitle:    game.Title,
+			GameName:     bet.GameName,
+			ResultStatus: "pending",
+			CreatedAt:    bet.CreatedAt, // Backfilled rounds keep the time they were played
+		}
+		// Rounds are shared by the bets of one user; concurrent ingests resolve to one row
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&round).Error; err != nil {
+			return nil, err
+		}
+		if round.ID == 0 {
+			if err := tx.Where("user_id = ? AND provider = ? AND vendor = ? AND round_id = ?",
+				bet.UserID, provider, game.Vendor, roundID).First(&round).Error; err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := tx.Model(bet).Update("casino_round_id", round.ID).Error; err != nil {
+		return nil, err
+	}
+	if err := RefreshCasinoRound(tx, &round); err != nil {
+		return nil, err
+	}
+	return &round, nil
+}
+
+// RefreshCasinoRound recomputes the totals and status of round from its casino bets, leaving
+// out rolled back transactions
+func RefreshCasinoRound(tx *gorm.DB, round *models.CasinoRound) error {
+	var bets []models.CasinoBet
+	if err := tx.Where("casino_round_id = ?", round.ID).Order("id ASC").Find(&bets).Error; err != nil {
+		return err
+	}
+
+	round.BetAmount, round.WinAmount = 0, 0
+	round.BetCount, round.WinCount = 0, 0
+	round.BetID, round.WinID = 0, 0
+	round.SettledAt = nil
+	active := 0
+	for _, bet := range bets {
+		if bet.Status == "rollback" {
+			continue
+		}
+		active++
+
+		switch bet.Type {
+		case "bet":
+			if round.BetCount == 0 {
+				round.BetID = bet.ID
+				round.TransID = bet.TransID
+				round.BeforeAmount = bet.BeforeAmount
+				round.BettingTime = bet.BettingTime
+				round.Status = bet.Status
+			}
+			round.BetAmount += bet.Amount
+			round.BetCount++
+		case "win":
+			round.WinAmount += bet.Amount
+			round.WinCount++
+			round.WinID = bet.ID
+			settledAt := bet.CreatedAt
+			round.SettledAt = &settledAt
+		}
+		round.AfterAmount = bet.AfterAmount
+		round.Details = map[string]interface{}{"game": CasinoBetGame(bet.Details)}
+		if round.TransID == "" {
+			round.TransID = bet.TransID
+			round.BettingTime = bet.BettingTime
+			round.Status = bet.Status
+		}
+	}
+	round.NetAmount = round.WinAmount + round.BetAmount
+
+	switch {
+	case active == 0 && len(bets) > 0:
+		round.ResultStatus = "cancelled"
+	case round.WinCount == 0:
+		round.ResultStatus = "pending"
+	case round.WinAmount > 0:
+		round.ResultStatus = "won"
+	default:
+		round.ResultStatus = "lost"
+	}
+
+	return tx.Save(round).Error
+}
+
+// RefreshCasinoRoundOf refreshes the round of the casino bet with transaction ID transID, after
+// the bet was rolled back
+func RefreshCasinoRoundOf(tx *gorm.DB, transID string) error {
+	var bet models.CasinoBet
+	if err := tx.Where("trans_id = ? AND casino_round_id IS NOT NULL", transID).First(&bet).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	var round models.CasinoRound
+	if err := tx.First(&round, *bet.CasinoRoundID).Error; err != nil {
+		return err
+	}
+	return RefreshCasinoRound(tx, &round)
+}
+
+// BackfillCasinoRounds links the casino bets ingested before rounds existed, oldest first.
+// Bets without a provider record are assumed to come from the default provider.
+func BackfillCasinoRounds(db *gorm.DB) (int, error) {
+	linked := 0
+	var bets []models.CasinoBet
+	result := db.Where("casino_round_id IS NULL AND type IN ?", []string{"bet", "win"}).
+		Order("id ASC").
+		FindInBatches(&bets, 500, func(batch *gorm.DB, _ int) error {
+			for i := range bets {
+				provider := casino.DefaultProvider
+				var walletTx models.CasinoWalletTransaction
+				if err := db.Select("provider").Where("transaction_id = ?", bets[i].TransID).
+					First(&walletTx).Error; err == nil {
+					provider = walletTx.Provider
+				}
+
+				err := db.Transaction(func(tx *gorm.DB) error {
+					_, err := RecordCasinoRound(tx, provider, &bets[i])
+					return err
+				})
+				if err != nil {
+					return fmt.Errorf("casino bet %d: %w", bets[i].ID, err)
+				}
+				linked++
+			}
+			return nil
+		})
+	return linked, result.Error
+}
+
+// CasinoRoundFilter narrows a casino round history listing
+type CasinoRoundFilter struct {
+	ParentID       uint   // Only users directly under this partner
+	GameNameFilter string // "slot" or "not_slot"
+	Status         string // "bet", "win", "won", "lost", "pending" or "cancelled"
+	DateFrom       string
+	DateTo         string
+	Search         string // Round ID, transaction ID, nickname or phone
+}
+
+// CasinoRoundQuery returns the casino round history query of filter
+func CasinoRoundQuery(db *gorm.DB, filter CasinoRoundFilter) *gorm.DB {
+	query := db.Model(&models.CasinoRound{})
+
+	if filter.ParentID != 0 || filter.Search != "" {
+		query = query.Joins("JOIN users ON users.id = casino_rounds.user_id")
+	}
+	if filter.ParentID != 0 {
+		query = query.Where("users.parent_id = ?", filter.ParentID)
+	}
+
+	switch filter.GameNameFilter {
+	case "slot":
+		query = query.Where("casino_rounds.game_type = ?", CasinoGameTypeSlot)
+	case "not_slot":
+		query = query.Where("casino_rounds.game_type <> ?", CasinoGameTypeSlot)
+	}
+
+	switch filter.Status {
+	case "":
+	case "bet":
+		query = query.Where("casino_rounds.bet_count > 0")
+	case "win":
+		query = query.Where("casino_rounds.win_count > 0")
+	default:
+		query = query.Where("casino_rounds.result_status = ?", filter.Status)
+	}
+
+	if filter.DateFrom != "" {
+		query = query.Where("casino_rounds.created_at >= ?", filter.DateFrom)
+	}
+	if filter.DateTo != "" {
+		query = query.Where("casino_rounds.created_at <= ?", filter.DateTo)
+	}
+
+	if filter.Search != "" {
+		searchPattern := "%" + filter.Search + "%"
+		query = query.Joins("JOIN profiles ON profiles.user_id = users.id").
+			Where("CAST(casino_rounds.id AS TEXT) LIKE ? OR casino_rounds.trans_id LIKE ? OR casino_rounds.round_id LIKE ? OR profiles.nickname LIKE ? OR profiles.phone LIKE ?",
+				searchPattern, searchPattern, searchPattern, searchPattern, searchPattern)
+	}
+
+	return query
+}
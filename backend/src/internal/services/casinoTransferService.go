@@ -0,0 +1,360 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hotbrainy/go-betting/backend/internal/casino"
+	"github.com/hotbrainy/go-betting/backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// casinoTransferTolerance absorbs rounding when comparing casino balances
+const casinoTransferTolerance = 0.01
+
+// CasinoTransferGrace is how long an unfinished transfer is left to its request before the
+// recovery worker takes it over; a provider call may still land within it
+const CasinoTransferGrace = 2 * time.Minute
+
+var (
+	ErrCasinoTransferNothing = errors.New("no balance to transfer")
+	ErrCasinoTransferFunds   = errors.New("insufficient balance for the transfer")
+	// The provider call failed and did not visibly apply; the recovery worker settles the transfer
+	ErrCasinoTransferPending = errors.New("casino transfer is pending")
+	ErrCasinoTransferSettled = errors.New("casino transfer is already settled")
+)
+
+// TransferToCasino moves amount, or the whole profile balance when amount is 0, from the
+// user's profile to their casino account on provider, capped by the user's daily casino
+// limits. The profile is debited before the provider is called so that the amount cannot be
+// spent twice.
+func TransferToCasino(ctx context.Context, db *gorm.DB, provider casino.Provider, user models.User, amount float64) (*models.CasinoTransfer, error) {
+	if amount < 0 {
+		return nil, ErrCasinoTransferNothing
+	}
+	if err := provider.EnsureUser(ctx, user.Userid); err != nil {
+		return nil, err
+	}
+	remoteBefore, err := provider.Balance(ctx, user.Userid)
+	if err != nil {
+		return nil, err
+	}
+
+	transfer := models.CasinoTransfer{
+		UserID:              user.ID,
+		Provider:            provider.Name(),
+		Direction:           "deposit",
+		Status:              "pending",
+		RemoteBalanceBefore: remoteBefore,
+	}
+	err = db.Transaction(func(tx *gorm.DB) error {
+		var profile models.Profile
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("user_id = ?", user.ID).First(&profile).Error; err != nil {
+			return err
+		}
+
+		if amount == 0 {
+			amount = profile.Balance
+		}
+		if amount <= 0 {
+			return ErrCasinoTransferNothing
+		}
+		if amount > profile.Balance {
+			return ErrCasinoTransferFunds
+		}
+
+		// Keep what can be lost in the casino within the user's daily limits
+		capped, err := CapCasinoTransfer(tx, user.ID, remoteBefore, amount)
+		if err != nil {
+			return err
+		}
+		amount = capped
+
+		transfer.Amount = amount
+		transfer.LocalBalanceBefore = profile.Balance
+		transfer.LocalBalanceAfter = profile.Balance - amount
+		if err := tx.Model(&profile).Update("balance", transfer.LocalBalanceAfter).Error; err != nil {
+			return err
+		}
+
+		ledger := models.Transaction{
+			UserID:        user.ID,
+			Amount:        amount,
+			Type:          "DepositCasino",
+			Shortcut:      "Casino",
+			Explation:     "DepositCasino",
+			BalanceBefore: transfer.LocalBalanceBefore,
+			BalanceAfter:  transfer.LocalBalanceAfter,
+			Status:        "pending",
+			TransactionAt: time.Now(),
+		}
+		if err := tx.Create(&ledger).Error; err != nil {
+			return err
+		}
+		transfer.TransactionID = &ledger.ID
+		return tx.Create(&transfer).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := provider.Deposit(ctx, user.Userid, amount, transfer.Reference()); err != nil {
+		return &transfer, settleFailedCasinoTransfer(ctx, db, provider, user.Userid, &transfer, err)
+	}
+	return &transfer, finishCasinoTransfer(db, &transfer)
+}
+
+// TransferFromCasino moves amount, or the whole casino balance when amount is 0, from the
+// user's casino account on provider back to their profile
+func TransferFromCasino(ctx context.Context, db *gorm.DB, provider casino.Provider, user models.User, amount float64) (*models.CasinoTransfer, error) {
+	if amount < 0 {
+		return nil, ErrCasinoTransferNothing
+	}
+	remoteBefore, err := provider.Balance(ctx, user.Userid)
+	if err != nil {
+		return nil, err
+	}
+
+	if amount == 0 {
+		amount = remoteBefore
+	}
+	if amount <= 0 {
+		return nil, ErrCasinoTransferNothing
+	}
+	if amount > remoteBefore+casinoTransferTolerance {
+		return nil, ErrCasinoTransferFunds
+	}
+
+	transfer := models.CasinoTransfer{
+		UserID:              user.ID,
+		Provider:            provider.Name(),
+		Direction:           "withdraw",
+		Amount:              amount,
+		Status:              "pending",
+		RemoteBalanceBefore: remoteBefore,
+	}
+	if err := db.Create(&transfer).Error; err != nil {
+		return nil, err
+	}
+
+	if err := provider.Withdraw(ctx, user.Userid, amount, transfer.Reference()); err != nil {
+		return &transfer, settleFailedCasinoTransfer(ctx, db, provider, user.Userid, &transfer, err)
+	}
+	return &transfer, finishCasinoTransfer(db, &transfer)
+}
+
+// settleFailedCasinoTransfer handles a provider call that returned callErr: a provider that
+// confirms transfers by reference is asked whether it applied the transfer anyway, otherwise the
+// transfer stays pending for the recovery worker, as the call may still land
+func settleFailedCasinoTransfer(ctx context.Context, db *gorm.DB, provider casino.Provider, username string, transfer *models.CasinoTransfer, callErr error) error {
+	db.Model(transfer).Update("last_error", callErr.Error())
+
+	if confirmer, ok := provider.(casino.TransferConfirmer); ok {
+		applied, err := confirmer.TransferApplied(ctx, username, transfer.Reference())
+		if err == nil && applied {
+			return finishCasinoTransfer(db, transfer)
+		}
+	}
+	return fmt.Errorf("%w: %v", ErrCasinoTransferPending, callErr)
+}
+
+// moveCasinoTransfer advances transfer from status from to status to inside tx, failing with
+// ErrCasinoTransferPending when another worker moved it first
+func moveCasinoTransfer(tx *gorm.DB, transfer *models.CasinoTransfer, from, to string, updates map[string]interface{}) error {
+	updates["status"] = to
+	result := tx.Model(&models.CasinoTransfer{}).
+		Where("id = ? AND status = ?", transfer.ID, from).
+		Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%w: transfer %d is no longer %s", ErrCasinoTransferPending, transfer.ID, from)
+	}
+	transfer.Status = to
+	return nil
+}
+
+// finishCasinoTransfer records that the provider applied transfer and commits the local side:
+// the reserved deposit is booked, the withdrawn amount is credited to the profile
+func finishCasinoTransfer(db *gorm.DB, transfer *models.CasinoTransfer) error {
+	if transfer.Status == "pending" || transfer.Status == "review" {
+		if err := moveCasinoTransfer(db, transfer, transfer.Status, "remote_confirmed", map[string]interface{}{}); err != nil {
+			return err
+		}
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		updates := map[string]interface{}{"completed_at": now, "last_error": ""}
+
+		if transfer.Direction == "deposit" {
+			if transfer.TransactionID != nil {
+				if err := tx.Model(&models.Transaction{}).Where("id = ?", *transfer.TransactionID).
+					Updates(map[string]interface{}{"status": "success", "approved_at": now}).Error; err != nil {
+					return err
+				}
+			}
+			return moveCasinoTransfer(tx, transfer, "remote_confirmed", "committed", updates)
+		}
+
+		var profile models.Profile
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("user_id = ?", transfer.UserID).First(&profile).Error; err != nil {
+			return err
+		}
+		balanceAfter := profile.Balance + transfer.Amount
+		if err := tx.Model(&profile).Update("balance", balanceAfter).Error; err != nil {
+			return err
+		}
+
+		ledger := models.Transaction{
+			UserID:        transfer.UserID,
+			Amount:        transfer.Amount,
+			Type:          "WithdrawalCasino",
+			Shortcut:      "Casino",
+			Explation:     "WithdrawalCasino",
+			BalanceBefore: profile.Balance,
+			BalanceAfter:  balanceAfter,
+			Status:        "success",
+			TransactionAt: now,
+			ApprovedAt:    now,
+		}
+		if err := tx.Create(&ledger).Error; err != nil {
+			return err
+		}
+
+		updates["transaction_id"] = ledger.ID
+		updates["local_balance_before"] = profile.Balance
+		updates["local_balance_after"] = balanceAfter
+		if err := moveCasinoTransfer(tx, transfer, "remote_confirmed", "committed", updates); err != nil {
+			return err
+		}
+		transfer.TransactionID = &ledger.ID
+		transfer.LocalBalanceBefore = profile.Balance
+		transfer.LocalBalanceAfter = balanceAfter
+		return nil
+	})
+}
+
+// compensateCasinoTransfer gives up transfer, which the provider never applied: a reserved
+// deposit is returned to the profile, a withdrawal has nothing to undo
+func compensateCasinoTransfer(db *gorm.DB, transfer *models.CasinoTransfer) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		if err := moveCasinoTransfer(tx, transfer, transfer.Status, "compensated", map[string]interface{}{"completed_at": now}); err != nil {
+			return err
+		}
+		if transfer.Direction != "deposit" {
+			return nil
+		}
+
+		var profile models.Profile
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("user_id = ?", transfer.UserID).First(&profile).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&profile).Update("balance", profile.Balance+transfer.Amount).Error; err != nil {
+			return err
+		}
+		if transfer.TransactionID == nil {
+			return nil
+		}
+		return tx.Model(&models.Transaction{}).Where("id = ?", *transfer.TransactionID).
+			Update("status", "cancelled").Error
+	})
+}
+
+// ResolveCasinoTransfer finishes or compensates an unfinished transfer: a transfer the provider
+// confirmed is committed locally, a pending one is decided by asking the provider about its
+// reference. The casino balance cannot tell, as play moves it, so a pending transfer of a
+// provider that cannot confirm references goes to review for admins to decide.
+func ResolveCasinoTransfer(ctx context.Context, db *gorm.DB, provider casino.Provider, transfer *models.CasinoTransfer) error {
+	switch transfer.Status {
+	case "remote_confirmed":
+		return finishCasinoTransfer(db, transfer)
+	case "pending":
+	default:
+		return nil
+	}
+
+	confirmer, ok := provider.(casino.TransferConfirmer)
+	if !ok {
+		return reviewCasinoTransfer(db, transfer)
+	}
+
+	var user models.User
+	if err := db.Select("id", "userid").First(&user, transfer.UserID).Error; err != nil {
+		return err
+	}
+	applied, err := confirmer.TransferApplied(ctx, user.Userid, transfer.Reference())
+	if err != nil {
+		return err
+	}
+	if applied {
+		return finishCasinoTransfer(db, transfer)
+	}
+	return compensateCasinoTransfer(db, transfer)
+}
+
+// reviewCasinoTransfer hands a pending transfer whose outcome cannot be confirmed to admins
+func reviewCasinoTransfer(db *gorm.DB, transfer *models.CasinoTransfer) error {
+	if err := moveCasinoTransfer(db, transfer, "pending", "review", map[string]interface{}{}); err != nil {
+		return err
+	}
+
+	title := "Casino Transfer Review"
+	message := fmt.Sprintf("Casino %s #%d of %.2f for user ID %d on %s could not be confirmed; check the casino account and settle it",
+		transfer.Direction, transfer.ID, transfer.Amount, transfer.UserID, transfer.Provider)
+	if _, err := CreateAlert(db, "casinoTransfer", title, message, transfer.ID, "/admin/casino/transfers"); err != nil {
+		fmt.Printf("Error creating casino transfer alert: %v\n", err)
+	}
+	return nil
+}
+
+// SettleCasinoTransfer settles a pending or reviewed transfer as an admin decided it: applied
+// when the provider moved the amount, which commits it locally, compensated otherwise
+func SettleCasinoTransfer(db *gorm.DB, transfer *models.CasinoTransfer, applied bool) error {
+	if transfer.Status != "pending" && transfer.Status != "review" {
+		return ErrCasinoTransferSettled
+	}
+	if applied {
+		return finishCasinoTransfer(db, transfer)
+	}
+	return compensateCasinoTransfer(db, transfer)
+}
+
+// RecoverCasinoTransfers resolves the transfers left unfinished for longer than
+// CasinoTransferGrace, returning how many were settled or sent to review
+func RecoverCasinoTransfers(ctx context.Context, db *gorm.DB) (int, error) {
+	var transfers []models.CasinoTransfer
+	if err := db.Where("status IN ? AND updated_at < ?", []string{"pending", "remote_confirmed"}, time.Now().Add(-CasinoTransferGrace)).
+		Order("id ASC").Find(&transfers).Error; err != nil {
+		return 0, err
+	}
+
+	settled := 0
+	var errs []error
+	for i := range transfers {
+		transfer := &transfers[i]
+
+		provider, err := casino.Get(transfer.Provider)
+		if err == nil {
+			err = ResolveCasinoTransfer(ctx, db, provider, transfer)
+		}
+		if err != nil {
+			db.Model(transfer).Updates(map[string]interface{}{
+				"attempts":   gorm.Expr("attempts + 1"),
+				"last_error": err.Error(),
+			})
+			errs = append(errs, fmt.Errorf("casino transfer %d: %w", transfer.ID, err))
+			continue
+		}
+		settled++
+	}
+	return settled, errors.Join(errs...)
+}
@@ -0,0 +1,97 @@
+package services
+
+import (
+	"errors"
+	"time"
+
+	"github.com/hotbrainy/go-betting/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrCasinoVendorDisabled    = errors.New("casino vendor is disabled")
+	ErrCasinoVendorMaintenance = errors.New("casino vendor is under maintenance")
+	ErrCasinoVendorLevel       = errors.New("casino vendor is not available at this level")
+	ErrCasinoVendorDomain      = errors.New("casino vendor is not available on this domain")
+)
+
+// CasinoVendorRecord returns the admin record of vendor, nil when it has none
+func CasinoVendorRecord(db *gorm.DB, vendor string) (*models.CasinoVendor, error) {
+	var record models.CasinoVendor
+	err := db.Where("vendor = ?", CasinoVendorKey(vendor)).First(&record).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// CheckCasinoVendorAccess tells whether the user may launch a game of vendor now from the
+// domain domainID. category ("live", "slot" or "holdem") picks the level's game access flag
+// unless the vendor record sets its own. A userID of 0 skips the level checks, a domainID of
+// 0 fails any domain restriction. The vendor record is returned along with the error so that
+// its maintenance message can be shown.
+func CheckCasinoVendorAccess(db *gorm.DB, vendor, category string, userID, domainID uint) (*models.CasinoVendor, error) {
+	record, err := CasinoVendorRecord(db, vendor)
+	if err != nil {
+		return nil, err
+	}
+
+	if record != nil {
+		if !record.Enabled {
+			return record, ErrCasinoVendorDisabled
+		}
+		if record.InMaintenance(time.Now()) {
+			return record, ErrCasinoVendorMaintenance
+		}
+		if len(record.DomainIDs) > 0 && !casinoVendorOnDomain(record, domainID) {
+			return record, ErrCasinoVendorDomain
+		}
+		if record.Category != "" {
+			category = record.Category
+		}
+	}
+
+	if userID == 0 {
+		return record, nil
+	}
+
+	var profile models.Profile
+	if err := db.Where("user_id = ?", userID).First(&profile).Error; err != nil {
+		return record, err
+	}
+	if record != nil && profile.Level < record.MinLevel {
+		return record, ErrCasinoVendorLevel
+	}
+
+	var level models.Level
+	if err := db.Where("level_number = ?", profile.Level).First(&level).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return record, nil
+		}
+		return record, err
+	}
+
+	access := level.LiveGameAccess
+	switch category {
+	case CasinoCategorySlot:
+		access = level.SlotGameAccess
+	case CasinoCategoryHoldem:
+		access = level.HoldemGameAccess
+	}
+	if !access {
+		return record, ErrCasinoVendorLevel
+	}
+	return record, nil
+}
+
+func casinoVendorOnDomain(record *models.CasinoVendor, domainID uint) bool {
+	for _, id := range record.DomainIDs {
+		if id == domainID {
+			return true
+		}
+	}
+	return false
+}
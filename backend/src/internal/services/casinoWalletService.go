@@ -0,0 +1,348 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"time"
+
+	"github.com/hotbrainy/go-betting/backend/internal/casino"
+	"github.com/hotbrainy/go-betting/backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrCasinoWalletUser        = errors.New("user not found")
+	ErrCasinoWalletFunds       = errors.New("insufficient funds")
+	ErrCasinoWalletAmount      = errors.New("invalid amount")
+	ErrCasinoWalletDuplicateTx = errors.New("transaction id already used for a different request")
+)
+
+// CasinoWalletSeamless reports whether casino play settles against our own ledger through
+// wallet callbacks (CASINO_WALLET_MODE=seamless) instead of moving the balance to HonorLink
+func CasinoWalletSeamless() bool {
+	return os.Getenv("CASINO_WALLET_MODE") == "seamless"
+}
+
+// CasinoWalletRequest is a debit, credit or rollback callback of a casino aggregator
+type CasinoWalletRequest struct {
+	Provider      string
+	TransactionID string
+	Username      string
+	RoundID       string
+	Vendor        string
+	GameType      string
+	GameID        string
+	Amount        float64
+	ReferenceID   string // Rollbacks only: the provider transaction to undo
+}
+
+// CasinoWalletBalance returns the balance of the user with userid username
+func CasinoWalletBalance(db *gorm.DB, username string) (float64, error) {
+	var user models.User
+	if err := db.Where("userid = ?", username).First(&user).Error; err != nil {
+		return 0, ErrCasinoWalletUser
+	}
+
+	var profile models.Profile
+	if err := db.Where("user_id = ?", user.ID).First(&profile).Error; err != nil {
+		return 0, err
+	}
+	return profile.Balance, nil
+}
+
+// ApplyCasinoWalletTransaction settles a "debit", "credit" or "rollback" callback against the
+// user's balance in one transaction. A transaction ID seen before returns the recorded result
+// without touching the balance again.
+func ApplyCasinoWalletTransaction(db *gorm.DB, action string, req CasinoWalletRequest) (*models.CasinoWalletTransaction, error) {
+	if req.Amount < 0 || math.IsNaN(req.Amount) || math.IsInf(req.Amount, 0) {
+		return nil, ErrCasinoWalletAmount
+	}
+	if action == "debit" && req.Amount == 0 {
+		return nil, ErrCasinoWalletAmount
+	}
+
+	var user models.User
+	if err := db.Where("userid = ?", req.Username).First(&user).Error; err != nil {
+		return nil, ErrCasinoWalletUser
+	}
+
+	tx := db.Begin()
+	walletTx, err := applyCasinoWalletTransaction(tx, action, user, req)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if err := tx.Commit().Error; err != nil {
+		return nil, err
+	}
+
+	if walletTx.Shortfall > 0 {
+		title := "Casino Rollback Shortfall"
+		message := fmt.Sprintf("User %s (ID: %d): rollback %s of win %s on %s took back %.2f, %.2f short of the win",
+			user.Userid, user.ID, walletTx.TransactionID, walletTx.ReferenceID, walletTx.Provider, -walletTx.Amount, walletTx.Shortfall)
+		if _, err := CreateAlert(db, "casinoShortfall", title, message, walletTx.ID, "/admin/casino/wallet"); err != nil {
+			fmt.Printf("Error creating casino rollback shortfall alert: %v\n", err)
+		}
+	}
+	return walletTx, nil
+}
+
+// casinoWalletRolledBack reports whether the provider already sent a rollback of transactionID,
+// which then stands as a tombstone for the transaction
+func casinoWalletRolledBack(tx *gorm.DB, provider string, userID uint, transactionID string) (bool, error) {
+	var rollbacks int64
+	err := tx.Model(&models.CasinoWalletTransaction{}).
+		Where("provider = ? AND user_id = ? AND action = ? AND reference_id = ?", provider, userID, "rollback", transactionID).
+		Count(&rollbacks).Error
+	return rollbacks > 0, err
+}
+
+func applyCasinoWalletTransaction(tx *gorm.DB, action string, user models.User, req CasinoWalletRequest) (*models.CasinoWalletTransaction, error) {
+	// Lock the balance first so that retries of the same callback run one after the other
+	var profile models.Profile
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("user_id = ?", user.ID).First(&profile).Error; err != nil {
+		return nil, err
+	}
+
+	var existing models.CasinoWalletTransaction
+	if err := tx.Where("provider = ? AND transaction_id = ?", req.Provider, req.TransactionID).
+		First(&existing).Error; err == nil {
+		if existing.Action != action || existing.UserID != user.ID {
+			return nil, ErrCasinoWalletDuplicateTx
+		}
+		return &existing, nil
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	walletTx := models.CasinoWalletTransaction{
+		Provider:      req.Provider,
+		TransactionID: req.TransactionID,
+		Action:        action,
+		UserID:        user.ID,
+		RoundID:       req.RoundID,
+		Vendor:        req.Vendor,
+		GameType:      req.GameType,
+		GameID:        req.GameID,
+		Amount:        req.Amount,
+		BalanceBefore: profile.Balance,
+		BalanceAfter:  profile.Balance,
+		ReferenceID:   req.ReferenceID,
+	}
+
+	// A debit or credit the provider rolled back before it reached us is recorded as rolled
+	// back without touching the balance
+	if action == "debit" || action == "credit" {
+		voided, err := casinoWalletRolledBack(tx, req.Provider, user.ID, req.TransactionID)
+		if err != nil {
+			return nil, err
+		}
+		if voided {
+			walletTx.RolledBack = true
+			if err := tx.Create(&walletTx).Error; err != nil {
+				return nil, err
+			}
+			return &walletTx, nil
+		}
+	}
+
+	var err error
+	switch action {
+	case "debit":
+		err = debitCasinoWallet(tx, &profile, &walletTx)
+	case "credit":
+		err = creditCasinoWallet(tx, &profile, &walletTx)
+	case "rollback":
+		err = rollbackCasinoWallet(tx, &profile, &walletTx)
+	default:
+		err = errors.New("unknown wallet action " + action)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Create(&walletTx).Error; err != nil {
+		return nil, err
+	}
+	return &walletTx, nil
+}
+
+// recordCasinoPlay writes the ledger and casino bet rows of a bet or win, the way the
+// HonorLink poller records them: bets carry a negative amount
+func recordCasinoPlay(tx *gorm.DB, walletTx *models.CasinoWalletTransaction, kind string, amount float64) error {
+	shortcut := walletTx.Vendor + "|" + walletTx.GameType
+	now := time.Now()
+
+	if err := tx.Create(&models.Transaction{
+		UserID:        walletTx.UserID,
+		Amount:        amount,
+		Type:          kind,
+		Shortcut:      shortcut,
+		Explation:     walletTx.TransactionID,
+		BalanceBefore: walletTx.BalanceBefore,
+		BalanceAfter:  walletTx.BalanceAfter,
+		Status:        "success",
+		TransactionAt: now,
+	}).Error; err != nil {
+		return err
+	}
+
+	bet := models.CasinoBet{
+		UserID:       walletTx.UserID,
+		Amount:       amount,
+		Type:         kind,
+		GameName:     shortcut,
+		TransID:      walletTx.TransactionID,
+		BeforeAmount: walletTx.BalanceBefore,
+		AfterAmount:  walletTx.BalanceAfter,
+		Status:       "success",
+		BettingTime:  uint(now.Unix()),
+		PlayedAt:     now,
+	}
+	SetCasinoBetGame(&bet, casinoWalletGame(walletTx))
+	if err := tx.Create(&bet).Error; err != nil {
+		return err
+	}
+	_, err := RecordCasinoRound(tx, walletTx.Provider, &bet)
+	return err
+}
+
+// casinoWalletGame is the game walletTx was played on
+func casinoWalletGame(walletTx *models.CasinoWalletTransaction) casino.GameRef {
+	return casino.GameRef{
+		Vendor: walletTx.Vendor,
+		Type:   walletTx.GameType,
+		ID:     walletTx.GameID,
+		Round:  walletTx.RoundID,
+	}
+}
+
+func debitCasinoWallet(tx *gorm.DB, profile *models.Profile, walletTx *models.CasinoWalletTransaction) error {
+	if profile.Balance < walletTx.Amount {
+		return ErrCasinoWalletFunds
+	}
+	if err := CheckCasinoStake(tx, walletTx.UserID, walletTx.Provider, walletTx.RoundID, walletTx.Amount); err != nil {
+		return err
+	}
+
+	walletTx.BalanceAfter = profile.Balance - walletTx.Amount
+	if err := tx.Model(profile).Updates(map[string]interface{}{
+		"balance": walletTx.BalanceAfter,
+		"wager":   profile.Wager + walletTx.Amount,
+	}).Error; err != nil {
+		return err
+	}
+
+	if err := recordCasinoPlay(tx, walletTx, "bet", -walletTx.Amount); err != nil {
+		return err
+	}
+
+	return AccrueCasinoPlay(tx, walletTx.UserID, "bet", casinoWalletGame(walletTx), -walletTx.Amount,
+		walletTx.TransactionID, time.Now())
+}
+
+func creditCasinoWallet(tx *gorm.DB, profile *models.Profile, walletTx *models.CasinoWalletTransaction) error {
+	walletTx.BalanceAfter = profile.Balance + walletTx.Amount
+	if err := tx.Model(profile).Update("balance", walletTx.BalanceAfter).Error; err != nil {
+		return err
+	}
+	if err := recordCasinoPlay(tx, walletTx, "win", walletTx.Amount); err != nil {
+		return err
+	}
+	return AccrueCasinoPlay(tx, walletTx.UserID, "win", casinoWalletGame(walletTx), walletTx.Amount,
+		walletTx.TransactionID, time.Now())
+}
+
+// rollbackCasinoWallet undoes the debit or credit walletTx.ReferenceID. Rolling back an already
+// rolled back transaction is recorded without changing the balance; rolling back one not seen
+// yet is recorded as a tombstone, so that the transaction is voided when it arrives. A win
+// is taken back only as far as the balance goes, the rest is recorded as the shortfall.
+func rollbackCasinoWallet(tx *gorm.DB, profile *models.Profile, walletTx *models.CasinoWalletTransaction) error {
+	walletTx.Amount = 0
+
+	var original models.CasinoWalletTransaction
+	err := tx.Where("provider = ? AND transaction_id = ? AND user_id = ?", walletTx.Provider, walletTx.ReferenceID, walletTx.UserID).
+		First(&original).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if original.RolledBack || original.Action == "rollback" {
+		return nil
+	}
+
+	now := time.Now()
+	updates := map[string]interface{}{}
+	switch original.Action {
+	case "debit":
+		walletTx.Amount = original.Amount
+		updates["wager"] = math.Max(profile.Wager-original.Amount, 0)
+
+		// Take back the rolling the stake earned, with its ledger row
+		var rolling models.Transaction
+		if err := tx.Where("user_id = ? AND type = ? AND explation = ?", walletTx.UserID, "Rolling", original.TransactionID+"_rolling").
+			First(&rolling).Error; err == nil {
+			rollAfter := profile.Roll - rolling.Amount
+			updates["roll"] = rollAfter
+			if err := tx.Create(&models.Transaction{
+				UserID:        walletTx.UserID,
+				Amount:        -rolling.Amount,
+				Type:          "Rolling",
+				Shortcut:      rolling.Shortcut,
+				Explation:     walletTx.TransactionID + "_rolling",
+				BalanceBefore: profile.Roll,
+				BalanceAfter:  rollAfter,
+				Status:        "success",
+				TransactionAt: now,
+			}).Error; err != nil {
+				return err
+			}
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		if err := updateCasinoStats(tx, walletTx.UserID, CasinoGameCategory(original.GameType), -original.Amount, 0); err != nil {
+			return err
+		}
+	case "credit":
+		reversal := math.Min(original.Amount, math.Max(profile.Balance, 0))
+		walletTx.Amount = -reversal
+		walletTx.Shortfall = original.Amount - reversal
+		if err := updateCasinoStats(tx, walletTx.UserID, CasinoGameCategory(original.GameType), 0, -original.Amount); err != nil {
+			return err
+		}
+	}
+	walletTx.BalanceAfter = profile.Balance + walletTx.Amount
+	updates["balance"] = walletTx.BalanceAfter
+
+	if err := tx.Model(profile).Updates(updates).Error; err != nil {
+		return err
+	}
+	if err := tx.Model(&original).Update("rolled_back", true).Error; err != nil {
+		return err
+	}
+	if err := tx.Model(&models.CasinoBet{}).Where("trans_id = ?", original.TransactionID).
+		Update("status", "rollback").Error; err != nil {
+		return err
+	}
+	if err := RefreshCasinoRoundOf(tx, original.TransactionID); err != nil {
+		return err
+	}
+
+	return tx.Create(&models.Transaction{
+		UserID:        walletTx.UserID,
+		Amount:        walletTx.Amount,
+		Type:          "casino_rollback",
+		Shortcut:      original.Vendor + "|" + original.GameType,
+		Explation:     walletTx.TransactionID,
+		BalanceBefore: walletTx.BalanceBefore,
+		BalanceAfter:  walletTx.BalanceAfter,
+		Status:        "success",
+		TransactionAt: now,
+	}).Error
+}
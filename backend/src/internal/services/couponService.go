@@ -0,0 +1,220 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hotbrainy/go-betting/backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrCouponInactive      = errors.New("coupon campaign is not active")
+	ErrCouponExpired       = errors.New("coupon has expired")
+	ErrCouponLevel         = errors.New("coupon is not available for this level")
+	ErrCouponUserLimit     = errors.New("coupon limit per user reached")
+	ErrCouponTotalLimit    = errors.New("coupon campaign is fully issued")
+	ErrCouponNotRedeemable = errors.New("coupon is not redeemable")
+	ErrCouponNoDeposit     = errors.New("a deposit is required after the coupon was issued")
+)
+
+// CheckCouponCampaignIssuable reports why coupons of the campaign cannot be issued to anyone,
+// or nil if they can
+func CheckCouponCampaignIssuable(campaign *models.CouponCampaign) error {
+	if !campaign.IsActive {
+		return ErrCouponInactive
+	}
+	if campaign.ExpiresAt != nil && campaign.ExpiresAt.Before(time.Now()) {
+		return ErrCouponExpired
+	}
+	if campaign.TotalLimit > 0 && campaign.IssuedCount >= campaign.TotalLimit {
+		return ErrCouponTotalLimit
+	}
+	return nil
+}
+
+// IssueCoupon issues one coupon of the campaign to the user inside tx.
+// It enforces activity, expiry, level, per-user and total limits and keeps Profile.Coupon in sync.
+// The campaign is reloaded locked, so concurrent issues of a campaign are counted one at a time.
+func IssueCoupon(tx *gorm.DB, campaign *models.CouponCampaign, userID uint, source string, issuedBy *uint) (*models.UserCoupon, error) {
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(campaign, campaign.ID).Error; err != nil {
+		return nil, err
+	}
+	if err := CheckCouponCampaignIssuable(campaign); err != nil {
+		return nil, err
+	}
+
+	var profile models.Profile
+	if err := tx.Where("user_id = ?", userID).First(&profile).Error; err != nil {
+		return nil, err
+	}
+
+	if campaign.MinLevel > 0 && int(profile.Level) < campaign.MinLevel {
+		return nil, ErrCouponLevel
+	}
+	if campaign.MaxLevel > 0 && int(profile.Level) > campaign.MaxLevel {
+		return nil, ErrCouponLevel
+	}
+
+	if campaign.PerUserLimit > 0 {
+		var owned int64
+		if err := tx.Model(&models.UserCoupon{}).
+			Where("campaign_id = ? AND user_id = ? AND status <> ?", campaign.ID, userID, "revoked").
+			Count(&owned).Error; err != nil {
+			return nil, err
+		}
+		if owned >= int64(campaign.PerUserLimit) {
+			return nil, ErrCouponUserLimit
+		}
+	}
+
+	coupon := models.UserCoupon{
+		CampaignID: campaign.ID,
+		UserID:     userID,
+		Status:     "issued",
+		Source:     source,
+		IssuedBy:   issuedBy,
+		ExpiresAt:  campaign.ExpiresAt,
+	}
+	if err := tx.Create(&coupon).Error; err != nil {
+		return nil, err
+	}
+
+	if err := tx.Model(&profile).Update("coupon", gorm.Expr("coupon + 1")).Error; err != nil {
+		return nil, err
+	}
+
+	if err := tx.Model(campaign).Update("issued_count", gorm.Expr("issued_count + 1")).Error; err != nil {
+		return nil, err
+	}
+	campaign.IssuedCount++
+
+	coupon.Campaign = *campaign
+	return &coupon, nil
+}
+
+// RedeemCoupon redeems an issued coupon inside tx, posting the ledger transaction and redemption history.
+func RedeemCoupon(tx *gorm.DB, coupon *models.UserCoupon) (*models.CouponRedemption, error) {
+	now := time.Now()
+
+	if coupon.Status != "issued" {
+		return nil, ErrCouponNotRedeemable
+	}
+
+	var campaign models.CouponCampaign
+	if err := tx.First(&campaign, coupon.CampaignID).Error; err != nil {
+		return nil, err
+	}
+
+	var profile models.Profile
+	if err := tx.Where("user_id = ?", coupon.UserID).First(&profile).Error; err != nil {
+		return nil, err
+	}
+
+	if coupon.ExpiresAt != nil && coupon.ExpiresAt.Before(now) {
+		if err := tx.Model(coupon).Update("status", "expired").Error; err != nil {
+			return nil, err
+		}
+		if profile.Coupon > 0 {
+			tx.Model(&profile).Update("coupon", profile.Coupon-1)
+		}
+		return nil, ErrCouponExpired
+	}
+
+	// Level is checked again because the user may have moved since issuance
+	if campaign.MinLevel > 0 && int(profile.Level) < campaign.MinLevel {
+		return nil, ErrCouponLevel
+	}
+	if campaign.MaxLevel > 0 && int(profile.Level) > campaign.MaxLevel {
+		return nil, ErrCouponLevel
+	}
+
+	amount := campaign.Amount
+	if campaign.Type == "deposit_match" {
+		var deposit models.Transaction
+		if err := tx.Where("user_id = ? AND type IN ? AND status = ? AND created_at >= ?",
+			coupon.UserID, []string{"deposit", "directDeposit"}, "A", coupon.CreatedAt).
+			Order("created_at DESC").
+			First(&deposit).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, ErrCouponNoDeposit
+			}
+			return nil, err
+		}
+		amount = deposit.Amount * campaign.Amount / 100
+		if campaign.MaxAmount > 0 && amount > campaign.MaxAmount {
+			amount = campaign.MaxAmount
+		}
+	}
+
+	balanceBefore := profile.Balance
+	balanceAfter := profile.Balance
+	pointBefore := float64(profile.Point)
+	pointAfter := float64(profile.Point)
+
+	updates := map[string]interface{}{}
+	if profile.Coupon > 0 {
+		updates["coupon"] = profile.Coupon - 1
+	}
+	switch campaign.Type {
+	case "point":
+		pointAfter = pointBefore + amount
+		updates["point"] = int32(pointAfter)
+	default:
+		balanceAfter = balanceBefore + amount
+		updates["balance"] = balanceAfter
+	}
+
+	if len(updates) > 0 {
+		if err := tx.Model(&profile).Updates(updates).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	transaction := models.Transaction{
+		UserID:        coupon.UserID,
+		Type:          "couponRedeem",
+		Amount:        amount,
+		BalanceBefore: balanceBefore,
+		BalanceAfter:  balanceAfter,
+		PointBefore:   pointBefore,
+		PointAfter:    pointAfter,
+		Explation:     fmt.Sprintf("Coupon #%d redeemed (campaign %d)", coupon.ID, campaign.ID),
+		Status:        "A",
+		ApprovedAt:    now,
+	}
+	if err := tx.Create(&transaction).Error; err != nil {
+		return nil, err
+	}
+
+	if err := tx.Model(coupon).Updates(map[string]interface{}{
+		"status":      "redeemed",
+		"redeemed_at": now,
+	}).Error; err != nil {
+		return nil, err
+	}
+
+	if err := tx.Model(&campaign).Update("redeemed_count", campaign.RedeemedCount+1).Error; err != nil {
+		return nil, err
+	}
+
+	redemption := models.CouponRedemption{
+		UserCouponID:  coupon.ID,
+		CampaignID:    campaign.ID,
+		UserID:        coupon.UserID,
+		TransactionID: transaction.ID,
+		Type:          campaign.Type,
+		Amount:        amount,
+		BalanceBefore: balanceBefore,
+		BalanceAfter:  balanceAfter,
+		PointBefore:   pointBefore,
+		PointAfter:    pointAfter,
+	}
+	if err := tx.Create(&redemption).Error; err != nil {
+		return nil, err
+	}
+
+	return &redemption, nil
+}
@@ -0,0 +1,48 @@
+package services
+
+import (
+	"errors"
+	"time"
+
+	"github.com/hotbrainy/go-betting/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// maxHonorLinkBackfill bounds the time range of one backfill
+const maxHonorLinkBackfill = 31 * 24 * time.Hour
+
+var ErrHonorLinkBackfillRange = errors.New("backfill range must end after it starts, not in the future, and span at most 31 days")
+
+// HonorLinkSyncHealth describes how far the HonorLink transaction sync trails behind
+type HonorLinkSyncHealth struct {
+	State      models.HonorLinkSyncState `json:"state"`
+	LagSeconds float64                   `json:"lagSeconds"` // Time since the cursor, -1 before the first sync
+}
+
+// GetHonorLinkSyncHealth returns the sync checkpoint and its lag
+func GetHonorLinkSyncHealth(db *gorm.DB) HonorLinkSyncHealth {
+	health := HonorLinkSyncHealth{LagSeconds: -1}
+	if err := db.First(&health.State).Error; err == nil {
+		health.LagSeconds = time.Since(health.State.Cursor).Seconds()
+	}
+	return health
+}
+
+// CreateHonorLinkBackfill queues a re-sync of the transactions created between start and end
+func CreateHonorLinkBackfill(db *gorm.DB, start, end time.Time, adminID *uint) (*models.HonorLinkBackfill, error) {
+	if !end.After(start) || end.After(time.Now()) || end.Sub(start) > maxHonorLinkBackfill {
+		return nil, ErrHonorLinkBackfillRange
+	}
+
+	backfill := models.HonorLinkBackfill{
+		Start:       start.UTC(),
+		End:         end.UTC(),
+		Status:      "pending",
+		Progress:    start.UTC(),
+		RequestedBy: adminID,
+	}
+	if err := db.Create(&backfill).Error; err != nil {
+		return nil, err
+	}
+	return &backfill, nil
+}
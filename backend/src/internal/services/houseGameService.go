@@ -0,0 +1,82 @@
+package services
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/hotbrainy/go-betting/backend/internal/minigame"
+	"github.com/hotbrainy/go-betting/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrHouseGameUnknown    = errors.New("not an in-house mini-game")
+	ErrHouseRoundNotDrawn  = errors.New("round has not been drawn yet")
+	ErrHouseSeedNotPresent = errors.New("no seed was committed for this round")
+)
+
+// HouseRoundSeed returns the committed seed of an in-house round, committing a new one
+// if the round has none yet. Seeds are never created for rounds that are already drawn.
+func HouseRoundSeed(tx *gorm.DB, round minigame.Round) (*models.MiniGameSeed, error) {
+	if !minigame.HouseGameTypes[round.GameType] {
+		return nil, ErrHouseGameUnknown
+	}
+
+	var seed models.MiniGameSeed
+	err := tx.Where("game_type = ? AND draw_date = ? AND round = ?", round.GameType, round.Date, round.Round).First(&seed).Error
+	if err == nil {
+		return &seed, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	// A seed created after the draw would prove nothing
+	if !time.Now().Before(round.DrawAt) {
+		return nil, ErrHouseSeedNotPresent
+	}
+
+	serverSeed, err := randomHex(32)
+	if err != nil {
+		return nil, err
+	}
+	hash := sha256.Sum256([]byte(serverSeed))
+	seed = models.MiniGameSeed{
+		GameType:       round.GameType,
+		DrawDate:       round.Date,
+		Round:          round.Round,
+		ServerSeed:     serverSeed,
+		ServerSeedHash: hex.EncodeToString(hash[:]),
+	}
+	if err := tx.Create(&seed).Error; err != nil {
+		// Another replica committed the round first; use its seed
+		if findErr := tx.Where("game_type = ? AND draw_date = ? AND round = ?", round.GameType, round.Date, round.Round).First(&seed).Error; findErr != nil {
+			return nil, err
+		}
+	}
+	return &seed, nil
+}
+
+// DrawHouseRound reveals the seed of a drawn in-house round and returns it with the draw
+func DrawHouseRound(tx *gorm.DB, round minigame.Round) (*models.MiniGameSeed, minigame.HouseDraw, error) {
+	if time.Now().Before(round.DrawAt) {
+		return nil, minigame.HouseDraw{}, ErrHouseRoundNotDrawn
+	}
+
+	seed, err := HouseRoundSeed(tx, round)
+	if err != nil {
+		return nil, minigame.HouseDraw{}, err
+	}
+
+	if seed.RevealedAt == nil {
+		now := time.Now()
+		if err := tx.Model(seed).Update("revealed_at", now).Error; err != nil {
+			return nil, minigame.HouseDraw{}, err
+		}
+		seed.RevealedAt = &now
+	}
+
+	return seed, minigame.NewHouseDraw(seed.ServerSeed, seed.GameType, seed.DrawDate, seed.Round), nil
+}
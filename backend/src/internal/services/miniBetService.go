@@ -0,0 +1,104 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hotbrainy/go-betting/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrMiniBetOptionNotFound = errors.New("bet option not found for this game and level")
+	ErrMiniBetOptionDisabled = errors.New("bet option is disabled")
+	ErrMiniBetOptionOdds     = errors.New("bet option has invalid odds")
+	ErrMiniGameInactive      = errors.New("mini game is not available for your level")
+)
+
+// MiniBetLimitError reports which betting limit an amount breaks
+type MiniBetLimitError struct {
+	Message string
+}
+
+func (e *MiniBetLimitError) Error() string {
+	return e.Message
+}
+
+// ResolveMiniBetOption loads the bet option optionID of gameType for level and returns it with
+// the odds in effect now and the odds version they belong to. Odds sent by the client are never used.
+func ResolveMiniBetOption(tx *gorm.DB, gameType string, level int, optionID uint) (*models.MiniBetOption, float64, *uint, error) {
+	var option models.MiniBetOption
+	if err := tx.Where("id = ? AND game_type = ? AND level = ?", optionID, gameType, level).First(&option).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, 0, nil, ErrMiniBetOptionNotFound
+		}
+		return nil, 0, nil, err
+	}
+
+	if !option.Enabled {
+		return nil, 0, nil, ErrMiniBetOptionDisabled
+	}
+
+	now := time.Now()
+	version, err := CurrentMiniOddsVersion(tx, gameType, now)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	effective, err := effectiveMiniOdds(tx, []uint{option.ID}, now)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	if o, ok := effective[option.ID]; ok {
+		option.Odds = o
+	}
+
+	odds, err := strconv.ParseFloat(option.Odds, 64)
+	if err != nil || odds <= 0 {
+		return nil, 0, nil, ErrMiniBetOptionOdds
+	}
+
+	var versionID *uint
+	if version != nil {
+		versionID = &version.ID
+	}
+	return &option, odds, versionID, nil
+}
+
+// CheckMiniBetLimits validates amount against the game's config for level and the level's
+// minimum mini-game bet. A missing config or level means no limits.
+func CheckMiniBetLimits(tx *gorm.DB, gameType string, level int, amount float64) error {
+	var levelSettings models.Level
+	if err := tx.Where("level_number = ?", level).First(&levelSettings).Error; err == nil {
+		if !levelSettings.MiniGameAccess {
+			return ErrMiniGameInactive
+		}
+		if levelSettings.MinigameMinimumBetAmount > 0 && amount < levelSettings.MinigameMinimumBetAmount {
+			return &MiniBetLimitError{
+				Message: fmt.Sprintf("The minimum mini-game bet for your level is %.0f", levelSettings.MinigameMinimumBetAmount),
+			}
+		}
+	}
+
+	var config models.MiniGameConfig
+	if err := tx.Where("game_type = ? AND level = ?", gameType, level).First(&config).Error; err != nil {
+		return nil
+	}
+
+	if !config.IsActive {
+		return ErrMiniGameInactive
+	}
+	if config.MinBettingValue > 0 && amount < config.MinBettingValue {
+		return &MiniBetLimitError{
+			Message: fmt.Sprintf("The minimum bet for this game is %.0f", config.MinBettingValue),
+		}
+	}
+	if config.MaxBettingValue > 0 && amount > config.MaxBettingValue {
+		return &MiniBetLimitError{
+			Message: fmt.Sprintf("The maximum bet for this game is %.0f", config.MaxBettingValue),
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,135 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hotbrainy/go-betting/backend/internal/minigame"
+	"github.com/hotbrainy/go-betting/backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var ErrMiniPickSuspended = errors.New("this pick is closed for the current round")
+
+// MiniExposureLimitError reports a bet that would exceed a round's exposure cap
+type MiniExposureLimitError struct {
+	Message string
+	Suspend bool // The pick should be suspended for the rest of the round
+}
+
+func (e *MiniExposureLimitError) Error() string {
+	return e.Message
+}
+
+// GetMiniGameExposureLimit returns the exposure caps of gameType; no row means no caps
+func GetMiniGameExposureLimit(db *gorm.DB, gameType string) models.MiniGameExposureLimit {
+	limit := models.MiniGameExposureLimit{GameType: gameType, Action: "reject"}
+	db.Where("game_type = ?", gameType).First(&limit)
+	return limit
+}
+
+// miniExposureRow returns the exposure row of pickKey in round, locked for the rest of tx. The
+// row is created first if missing; bets arriving together on a new round share the one row.
+func miniExposureRow(tx *gorm.DB, round minigame.Round, pickKey string) (*models.MiniGameExposure, error) {
+	row := models.MiniGameExposure{
+		GameType: round.GameType,
+		DrawDate: round.Date,
+		Round:    round.Round,
+		PickKey:  pickKey,
+	}
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&row).Error; err != nil {
+		return nil, err
+	}
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("game_type = ? AND draw_date = ? AND round = ? AND pick_key = ?", round.GameType, round.Date, round.Round, pickKey).
+		First(&row).Error; err != nil {
+		return nil, err
+	}
+	return &row, nil
+}
+
+// addMiniExposure adds a bet to row unless the payout would exceed max (0 = no cap)
+func addMiniExposure(tx *gorm.DB, row *models.MiniGameExposure, stake, payout, max float64) (bool, error) {
+	query := tx.Model(&models.MiniGameExposure{}).Where("id = ?", row.ID)
+	if max > 0 {
+		query = query.Where("payout + ? <= ?", payout, max)
+	}
+	result := query.Updates(map[string]interface{}{
+		"bets":   gorm.Expr("bets + 1"),
+		"stake":  gorm.Expr("stake + ?", stake),
+		"payout": gorm.Expr("payout + ?", payout),
+	})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// ReserveMiniBetExposure books the potential payout of a bet on its pick and round inside tx,
+// rejecting it when the pick is suspended or a cap would be exceeded
+func ReserveMiniBetExposure(tx *gorm.DB, round minigame.Round, pickKey string, stake, payout float64) error {
+	var suspended int64
+	if err := tx.Model(&models.MiniGameSuspension{}).
+		Where("game_type = ? AND draw_date = ? AND round = ? AND pick_key = ?", round.GameType, round.Date, round.Round, pickKey).
+		Count(&suspended).Error; err != nil {
+		return err
+	}
+	if suspended > 0 {
+		return ErrMiniPickSuspended
+	}
+
+	limit := GetMiniGameExposureLimit(tx, round.GameType)
+
+	pickRow, err := miniExposureRow(tx, round, pickKey)
+	if err != nil {
+		return err
+	}
+	ok, err := addMiniExposure(tx, pickRow, stake, payout, limit.MaxPickPayout)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return &MiniExposureLimitError{
+			Message: fmt.Sprintf("This pick has reached its betting limit for round %d", round.Round),
+			Suspend: limit.Action == "suspend",
+		}
+	}
+
+	// The empty pick key holds the round total
+	totalRow, err := miniExposureRow(tx, round, "")
+	if err != nil {
+		return err
+	}
+	ok, err = addMiniExposure(tx, totalRow, stake, payout, limit.MaxRoundPayout)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return &MiniExposureLimitError{
+			Message: fmt.Sprintf("Round %d has reached its betting limit", round.Round),
+		}
+	}
+
+	return nil
+}
+
+// SuspendMiniPick closes pickKey for the rest of round; suspending twice is a no-op
+func SuspendMiniPick(db *gorm.DB, round minigame.Round, pickKey, reason string, adminID *uint) (*models.MiniGameSuspension, error) {
+	suspension := models.MiniGameSuspension{
+		GameType:    round.GameType,
+		DrawDate:    round.Date,
+		Round:       round.Round,
+		PickKey:     pickKey,
+		Reason:      reason,
+		SuspendedBy: adminID,
+	}
+	if err := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&suspension).Error; err != nil {
+		return nil, err
+	}
+	if err := db.Where("game_type = ? AND draw_date = ? AND round = ? AND pick_key = ?", round.GameType, round.Date, round.Round, pickKey).
+		First(&suspension).Error; err != nil {
+		return nil, err
+	}
+	return &suspension, nil
+}
@@ -0,0 +1,98 @@
+package services
+
+import (
+	"github.com/hotbrainy/go-betting/backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RecordMiniGameDraw stores draw inside tx unless the round is already recorded, by an earlier
+// settlement pass or concurrently, in which case draw is loaded with the stored row so that
+// every bet of the round is graded against the same result
+func RecordMiniGameDraw(tx *gorm.DB, draw *models.MiniGameDraw) error {
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(draw).Error; err != nil {
+		return err
+	}
+	if draw.ID != 0 {
+		return nil
+	}
+	return tx.Where("game_type = ? AND draw_date = ? AND round = ?", draw.GameType, draw.DrawDate, draw.Round).
+		First(draw).Error
+}
+
+// DrawRun is a run of consecutive draws with the same outcome
+type DrawRun struct {
+	Value  string `json:"value"`
+	Length int    `json:"length"`
+}
+
+// DrawPattern summarises one outcome (odd/even, under/over, ...) over a series of draws
+type DrawPattern struct {
+	Runs    []DrawRun      `json:"runs"`    // Oldest first
+	Current DrawRun        `json:"current"` // Run the latest draw belongs to
+	Longest map[string]int `json:"longest"` // Longest run per value
+	Counts  map[string]int `json:"counts"`
+}
+
+// MiniGameDrawStats holds the pattern statistics of a series of draws
+type MiniGameDrawStats struct {
+	Draws               int         `json:"draws"`
+	PowerballOddEven    DrawPattern `json:"powerballOddEven"`
+	PowerballUnderOver  DrawPattern `json:"powerballUnderOver"`
+	NormalballOddEven   DrawPattern `json:"normalballOddEven"`
+	NormalballUnderOver DrawPattern `json:"normalballUnderOver"`
+	NormalballSize      DrawPattern `json:"normalballSize"`
+	NormalballSection   DrawPattern `json:"normalballSection"`
+}
+
+// BuildMiniGameDrawStats computes streaks and distributions of draws, which must be ordered oldest first
+func BuildMiniGameDrawStats(draws []models.MiniGameDraw) MiniGameDrawStats {
+	pick := func(value func(models.MiniGameDraw) string) DrawPattern {
+		values := make([]string, 0, len(draws))
+		for _, draw := range draws {
+			values = append(values, value(draw))
+		}
+		return buildDrawPattern(values)
+	}
+
+	return MiniGameDrawStats{
+		Draws:               len(draws),
+		PowerballOddEven:    pick(func(d models.MiniGameDraw) string { return d.PowBallOddEven }),
+		PowerballUnderOver:  pick(func(d models.MiniGameDraw) string { return d.PowBallUnderOver }),
+		NormalballOddEven:   pick(func(d models.MiniGameDraw) string { return d.DefBallOddEven }),
+		NormalballUnderOver: pick(func(d models.MiniGameDraw) string { return d.DefBallUnderOver }),
+		NormalballSize:      pick(func(d models.MiniGameDraw) string { return d.DefBallSize }),
+		NormalballSection:   pick(func(d models.MiniGameDraw) string { return d.DefBallSection }),
+	}
+}
+
+func buildDrawPattern(values []string) DrawPattern {
+	pattern := DrawPattern{
+		Runs:    []DrawRun{},
+		Longest: map[string]int{},
+		Counts:  map[string]int{},
+	}
+
+	for _, value := range values {
+		// Draws missing this outcome break the run without counting
+		if value == "" {
+			pattern.Current = DrawRun{}
+			continue
+		}
+
+		pattern.Counts[value]++
+		if pattern.Current.Value == value {
+			pattern.Current.Length++
+			pattern.Runs[len(pattern.Runs)-1].Length++
+		} else {
+			pattern.Current = DrawRun{Value: value, Length: 1}
+			pattern.Runs = append(pattern.Runs, pattern.Current)
+		}
+
+		if pattern.Current.Length > pattern.Longest[value] {
+			pattern.Longest[value] = pattern.Current.Length
+		}
+	}
+
+	return pattern
+}
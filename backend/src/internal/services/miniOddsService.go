@@ -0,0 +1,234 @@
+package services
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/hotbrainy/go-betting/backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrMiniOddsNoChanges        = errors.New("odds version changes no odds")
+	ErrMiniOddsVersionEffective = errors.New("odds version is already in effect")
+)
+
+// effectiveMiniOdds returns the versioned odds in effect at time at for optionIDs.
+// Options no effective version changes are missing from the map.
+func effectiveMiniOdds(tx *gorm.DB, optionIDs []uint, at time.Time) (map[uint]string, error) {
+	odds := map[uint]string{}
+	if len(optionIDs) == 0 {
+		return odds, nil
+	}
+
+	var rows []struct {
+		OptionID uint
+		Odds     string
+	}
+	if err := tx.Raw(`
+		SELECT DISTINCT ON (c.option_id) c.option_id, c.odds
+		FROM mini_odds_changes c
+		JOIN mini_odds_versions v ON v.id = c.version_id
+		WHERE c.option_id IN ? AND v.effective_from <= ? AND v.deleted_at IS NULL
+		ORDER BY c.option_id, v.effective_from DESC, v.id DESC
+	`, optionIDs, at).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		odds[row.OptionID] = row.Odds
+	}
+	return odds, nil
+}
+
+// ApplyEffectiveMiniOdds replaces the odds of options with those in effect now
+func ApplyEffectiveMiniOdds(tx *gorm.DB, options []models.MiniBetOption) error {
+	ids := make([]uint, 0, len(options))
+	for _, option := range options {
+		ids = append(ids, option.ID)
+	}
+
+	odds, err := effectiveMiniOdds(tx, ids, time.Now())
+	if err != nil {
+		return err
+	}
+	for i := range options {
+		if o, ok := odds[options[i].ID]; ok {
+			options[i].Odds = o
+		}
+	}
+	return nil
+}
+
+// EffectiveMiniBetOdds returns the odds of option in effect now
+func EffectiveMiniBetOdds(tx *gorm.DB, option *models.MiniBetOption) (string, error) {
+	odds, err := effectiveMiniOdds(tx, []uint{option.ID}, time.Now())
+	if err != nil {
+		return "", err
+	}
+	if o, ok := odds[option.ID]; ok {
+		return o, nil
+	}
+	return option.Odds, nil
+}
+
+// CurrentMiniOddsVersion returns the latest version of gameType in effect at time at, nil if none is
+func CurrentMiniOddsVersion(tx *gorm.DB, gameType string, at time.Time) (*models.MiniOddsVersion, error) {
+	var version models.MiniOddsVersion
+	err := tx.Where("game_type = ? AND effective_from <= ?", gameType, at).
+		Order("effective_from DESC, id DESC").
+		First(&version).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &version, nil
+}
+
+// CreateMiniOddsVersion records new odds (by option ID) for bet options of gameType, taking
+// effect at effectiveFrom. Odds equal to those already in effect then are left out. A version
+// effective immediately also updates MiniBetOption.Odds; a scheduled one is rolled forward by
+// ApplyDueMiniOddsVersions.
+func CreateMiniOddsVersion(tx *gorm.DB, gameType string, effectiveFrom time.Time, note string, adminID *uint, odds map[uint]string) (*models.MiniOddsVersion, error) {
+	ids := make([]uint, 0, len(odds))
+	for id, o := range odds {
+		if value, err := strconv.ParseFloat(o, 64); err != nil || value <= 0 {
+			return nil, ErrMiniBetOptionOdds
+		}
+		ids = append(ids, id)
+	}
+
+	var options []models.MiniBetOption
+	if err := tx.Where("id IN ? AND game_type = ?", ids, gameType).Find(&options).Error; err != nil {
+		return nil, err
+	}
+	if len(options) != len(ids) {
+		return nil, ErrMiniBetOptionNotFound
+	}
+
+	previous, err := effectiveMiniOdds(tx, ids, effectiveFrom)
+	if err != nil {
+		return nil, err
+	}
+
+	version := models.MiniOddsVersion{
+		GameType:      gameType,
+		EffectiveFrom: effectiveFrom,
+		Note:          note,
+		CreatedBy:     adminID,
+	}
+	for _, option := range options {
+		before, ok := previous[option.ID]
+		if !ok {
+			before = option.Odds
+		}
+		if before == odds[option.ID] {
+			continue
+		}
+		version.Changes = append(version.Changes, models.MiniOddsChange{
+			OptionID:     option.ID,
+			PreviousOdds: before,
+			Odds:         odds[option.ID],
+		})
+	}
+	if len(version.Changes) == 0 {
+		return nil, ErrMiniOddsNoChanges
+	}
+
+	// Deleted versions keep their numbers
+	var last int
+	if err := tx.Unscoped().Model(&models.MiniOddsVersion{}).
+		Where("game_type = ?", gameType).
+		Select("COALESCE(MAX(version), 0)").
+		Scan(&last).Error; err != nil {
+		return nil, err
+	}
+	version.Version = last + 1
+
+	now := time.Now()
+	immediate := !effectiveFrom.After(now)
+	if immediate {
+		version.AppliedAt = &now
+	}
+	if err := tx.Create(&version).Error; err != nil {
+		return nil, err
+	}
+
+	if immediate {
+		for _, change := range version.Changes {
+			if err := tx.Model(&models.MiniBetOption{}).Where("id = ?", change.OptionID).
+				Update("odds", change.Odds).Error; err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return &version, nil
+}
+
+// ApplyDueMiniOddsVersions rolls MiniBetOption.Odds forward to the scheduled versions that have
+// taken effect, so that every reader of the options sees the odds bets are placed at. It
+// returns the number of versions applied.
+func ApplyDueMiniOddsVersions(db *gorm.DB) (int, error) {
+	now := time.Now()
+
+	var due []models.MiniOddsVersion
+	if err := db.Where("applied_at IS NULL AND effective_from <= ?", now).
+		Order("effective_from ASC, id ASC").
+		Find(&due).Error; err != nil {
+		return 0, err
+	}
+
+	applied := 0
+	for _, candidate := range due {
+		err := db.Transaction(func(tx *gorm.DB) error {
+			var version models.MiniOddsVersion
+			if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Preload("Changes").
+				Where("applied_at IS NULL").First(&version, candidate.ID).Error; err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return nil // Applied by another instance
+				}
+				return err
+			}
+
+			ids := make([]uint, 0, len(version.Changes))
+			for _, change := range version.Changes {
+				ids = append(ids, change.OptionID)
+			}
+			// The odds in effect now, which a later version may have changed again
+			odds, err := effectiveMiniOdds(tx, ids, now)
+			if err != nil {
+				return err
+			}
+			for id, o := range odds {
+				if err := tx.Model(&models.MiniBetOption{}).Where("id = ?", id).
+					Update("odds", o).Error; err != nil {
+					return err
+				}
+			}
+
+			applied++
+			return tx.Model(&version).Update("applied_at", now).Error
+		})
+		if err != nil {
+			return applied, err
+		}
+	}
+	return applied, nil
+}
+
+// CancelMiniOddsVersion deletes a version that has not taken effect yet
+func CancelMiniOddsVersion(tx *gorm.DB, id uint) error {
+	var version models.MiniOddsVersion
+	if err := tx.First(&version, id).Error; err != nil {
+		return err
+	}
+	if !version.EffectiveFrom.After(time.Now()) {
+		return ErrMiniOddsVersionEffective
+	}
+	return tx.Delete(&version).Error
+}
@@ -0,0 +1,281 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hotbrainy/go-betting/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// maxUplineDepth bounds the walk up the partner tree in case of a cycle
+const maxUplineDepth = 20
+
+// miniRollingRate returns the user's own rolling rate (%) for single or combination mini bets,
+// falling back to the level rate when the user has none configured
+func miniRollingRate(user models.User, level *models.Level, combination bool) float64 {
+	rate := user.MiniDanpolRolling
+	if combination {
+		rate = user.MiniCombinationRolling
+	}
+	if rate > 0 || level == nil {
+		return rate
+	}
+	if combination {
+		return level.MiniCombinationRollingRate
+	}
+	return level.MiniDanpolRollingRate
+}
+
+// creditMiniRolling adds amount to the user's rolling and records it in the ledger
+func creditMiniRolling(tx *gorm.DB, userID uint, amount float64, explanation string) error {
+	var profile models.Profile
+	if err := tx.Where("user_id = ?", userID).First(&profile).Error; err != nil {
+		return err
+	}
+
+	rollAfter := profile.Roll + amount
+	if err := tx.Model(&profile).Update("roll", rollAfter).Error; err != nil {
+		return err
+	}
+
+	// For rolling, PointBefore/PointAfter represent rolling before/after
+	return tx.Create(&models.Transaction{
+		UserID:        userID,
+		Type:          "miniRolling",
+		Amount:        amount,
+		BalanceBefore: profile.Balance,
+		BalanceAfter:  profile.Balance,
+		PointBefore:   profile.Roll,
+		PointAfter:    rollAfter,
+		Explation:     explanation,
+		Status:        "A",
+		ApprovedAt:    time.Now(),
+	}).Error
+}
+
+// AccrueMiniBetSettlement books everything a settled mini bet earns besides its payout, inside tx:
+//   - rolling on the stake for the bettor at their single or combination rate, and for every
+//     upline partner at the difference between their rate and the rate already paid below them
+//   - points on a lost stake at the level's single or combination rate, or its overall
+//     mini-game rate when that is not set
+//   - the user's mini single/combination betting and winning statistics
+func AccrueMiniBetSettlement(tx *gorm.DB, bet *models.PowerballHistory, won bool, payout float64) error {
+	combination := bet.BetType == "combination"
+
+	var user models.User
+	if err := tx.First(&user, bet.UserID).Error; err != nil {
+		return err
+	}
+
+	var profile models.Profile
+	if err := tx.Where("user_id = ?", bet.UserID).First(&profile).Error; err != nil {
+		return err
+	}
+
+	var level *models.Level
+	var levelSettings models.Level
+	if err := tx.Where("level_number = ?", profile.Level).First(&levelSettings).Error; err == nil {
+		level = &levelSettings
+	}
+
+	// Statistics
+	stats := map[string]interface{}{}
+	if combination {
+		stats["mini_combination_betting"] = gorm.Expr("mini_combination_betting + ?", bet.Amount)
+		if won {
+			stats["mini_combination_winnings"] = gorm.Expr("mini_combination_winnings + ?", payout)
+		}
+	} else {
+		stats["mini_danpol_betting"] = gorm.Expr("mini_danpol_betting + ?", bet.Amount)
+		if won {
+			stats["mini_danpol_winner"] = gorm.Expr("mini_danpol_winner + ?", payout)
+		}
+	}
+	if err := tx.Model(&models.User{}).Where("id = ?", user.ID).Updates(stats).Error; err != nil {
+		return err
+	}
+
+	// Rolling for the bettor
+	paidRate := miniRollingRate(user, level, combination)
+	if paidRate > 0 {
+		if err := creditMiniRolling(tx, user.ID, bet.Amount*paidRate/100,
+			fmt.Sprintf("Mini rolling for bet #%d", bet.ID)); err != nil {
+			return err
+		}
+	}
+
+	// Rolling for the upline, each partner earning the part of their rate not paid below them
+	parentID := user.ParentID
+	for depth := 0; parentID != nil && depth < maxUplineDepth; depth++ {
+		var parent models.User
+		if err := tx.First(&parent, *parentID).Error; err != nil {
+			break
+		}
+
+		rate := miniRollingRate(parent, nil, combination)
+		if rate > paidRate {
+			if err := creditMiniRolling(tx, parent.ID, bet.Amount*(rate-paidRate)/100,
+				fmt.Sprintf("Mini rolling for member bet #%d", bet.ID)); err != nil {
+				return err
+			}
+			paidRate = rate
+		}
+		parentID = parent.ParentID
+	}
+
+	// Points on a lost stake
+	if won || level == nil {
+		return nil
+	}
+	pointRate := level.MinigameSinglePoleDrawPoint
+	if combination {
+		pointRate = level.MinigameCombinationWinningPoints
+	}
+	if pointRate <= 0 {
+		pointRate = level.TotalPointsLostInMinigames
+	}
+	points := int32(bet.Amount * pointRate / 100)
+	if points <= 0 {
+		return nil
+	}
+
+	// Reload, the rolling above may have changed the profile
+	if err := tx.Where("user_id = ?", bet.UserID).First(&profile).Error; err != nil {
+		return err
+	}
+	pointAfter := profile.Point + points
+	if err := tx.Model(&profile).Update("point", pointAfter).Error; err != nil {
+		return err
+	}
+
+	return tx.Create(&models.Transaction{
+		UserID:        bet.UserID,
+		Type:          "miniLossPoint",
+		Amount:        float64(points),
+		BalanceBefore: profile.Balance,
+		BalanceAfter:  profile.Balance,
+		PointBefore:   float64(profile.Point),
+		PointAfter:    float64(pointAfter),
+		Explation:     fmt.Sprintf("Points for lost mini bet #%d", bet.ID),
+		Status:        "A",
+		ApprovedAt:    time.Now(),
+	}).Error
+}
+
+// ReverseMiniBetSettlement undoes the settlement of a settled mini bet inside tx so that it can
+// be graded again: the payout is taken back, the rolling of the bettor and upline and the points
+// on a lost stake are reversed with ledger rows, the statistics are reduced and the bet is
+// pending again. What earlier reversals already took back is netted out.
+func ReverseMiniBetSettlement(tx *gorm.DB, bet *models.PowerballHistory) error {
+	now := time.Now()
+	betRef := fmt.Sprintf("%d", bet.ID)
+
+	// Payout
+	var payout float64
+	if err := tx.Model(&models.Transaction{}).Select("COALESCE(SUM(amount), 0)").
+		Where("user_id = ? AND type IN ? AND explation = ?", bet.UserID, []string{"minigame_Win", "minigame_regrade"}, betRef).
+		Scan(&payout).Error; err != nil {
+		return err
+	}
+	if payout != 0 {
+		var profile models.Profile
+		if err := tx.Where("user_id = ?", bet.UserID).First(&profile).Error; err != nil {
+			return err
+		}
+		balanceAfter := profile.Balance - payout
+		if err := tx.Model(&profile).Update("balance", balanceAfter).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(&models.Transaction{
+			UserID:        bet.UserID,
+			Type:          "minigame_regrade",
+			Amount:        -payout,
+			BalanceBefore: profile.Balance,
+			BalanceAfter:  balanceAfter,
+			Explation:     betRef,
+			Status:        "A",
+			ApprovedAt:    now,
+		}).Error; err != nil {
+			return err
+		}
+	}
+
+	// Rolling, per user as the upline earned part of it
+	var rollings []struct {
+		UserID uint
+		Amount float64
+	}
+	if err := tx.Model(&models.Transaction{}).Select("user_id, SUM(amount) AS amount").
+		Where("type = ? AND explation IN ?", "miniRolling", []string{
+			fmt.Sprintf("Mini rolling for bet #%d", bet.ID),
+			fmt.Sprintf("Mini rolling for member bet #%d", bet.ID),
+			fmt.Sprintf("Mini rolling reversed for bet #%d", bet.ID),
+		}).
+		Group("user_id").Scan(&rollings).Error; err != nil {
+		return err
+	}
+	for _, rolling := range rollings {
+		if rolling.Amount == 0 {
+			continue
+		}
+		if err := creditMiniRolling(tx, rolling.UserID, -rolling.Amount,
+			fmt.Sprintf("Mini rolling reversed for bet #%d", bet.ID)); err != nil {
+			return err
+		}
+	}
+
+	// Points on a lost stake
+	var points float64
+	if err := tx.Model(&models.Transaction{}).Select("COALESCE(SUM(amount), 0)").
+		Where("user_id = ? AND type = ? AND explation IN ?", bet.UserID, "miniLossPoint", []string{
+			fmt.Sprintf("Points for lost mini bet #%d", bet.ID),
+			fmt.Sprintf("Points reversed for mini bet #%d", bet.ID),
+		}).
+		Scan(&points).Error; err != nil {
+		return err
+	}
+	if points != 0 {
+		var profile models.Profile
+		if err := tx.Where("user_id = ?", bet.UserID).First(&profile).Error; err != nil {
+			return err
+		}
+		pointAfter := profile.Point - int32(points)
+		if err := tx.Model(&profile).Update("point", pointAfter).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(&models.Transaction{
+			UserID:        bet.UserID,
+			Type:          "miniLossPoint",
+			Amount:        -points,
+			BalanceBefore: profile.Balance,
+			BalanceAfter:  profile.Balance,
+			PointBefore:   float64(profile.Point),
+			PointAfter:    float64(pointAfter),
+			Explation:     fmt.Sprintf("Points reversed for mini bet #%d", bet.ID),
+			Status:        "A",
+			ApprovedAt:    now,
+		}).Error; err != nil {
+			return err
+		}
+	}
+
+	// Statistics
+	stats := map[string]interface{}{}
+	if bet.BetType == "combination" {
+		stats["mini_combination_betting"] = gorm.Expr("mini_combination_betting - ?", bet.Amount)
+		stats["mini_combination_winnings"] = gorm.Expr("mini_combination_winnings - ?", payout)
+	} else {
+		stats["mini_danpol_betting"] = gorm.Expr("mini_danpol_betting - ?", bet.Amount)
+		stats["mini_danpol_winner"] = gorm.Expr("mini_danpol_winner - ?", payout)
+	}
+	if err := tx.Model(&models.User{}).Where("id = ?", bet.UserID).Updates(stats).Error; err != nil {
+		return err
+	}
+
+	return tx.Model(bet).Updates(map[string]interface{}{
+		"status":  "pending",
+		"result":  "",
+		"draw_id": nil,
+	}).Error
+}
@@ -0,0 +1,289 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hotbrainy/go-betting/backend/internal/minigame"
+	"github.com/hotbrainy/go-betting/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+var ErrMiniRoundOpen = errors.New("round is still open for betting")
+
+// GetMiniGameSettlementConfig returns the settlement config, or the defaults when none is saved
+func GetMiniGameSettlementConfig(db *gorm.DB) models.MiniGameSettlementConfig {
+	config := models.MiniGameSettlementConfig{CancelAfterMinutes: 30}
+	db.First(&config)
+	return config
+}
+
+// PendingMiniRoundBets returns the pending bets placed while round was open
+func PendingMiniRoundBets(tx *gorm.DB, round minigame.Round) ([]models.PowerballHistory, error) {
+	var bets []models.PowerballHistory
+	err := tx.Where("round = ? AND game_type = ? AND status = ? AND created_at >= ? AND created_at < ?",
+		round.Round, round.GameType, "pending", round.OpenAt, round.DrawAt).Find(&bets).Error
+	return bets, err
+}
+
+// CancelMiniRound cancels every pending bet of round and refunds its stake inside tx, recording
+// the action in the audit trail. Bets settled concurrently are left alone.
+func CancelMiniRound(tx *gorm.DB, round minigame.Round, action, reason string, adminID *uint) (*models.MiniGameRoundAction, error) {
+	if time.Now().Before(round.CloseAt) {
+		return nil, ErrMiniRoundOpen
+	}
+
+	bets, err := PendingMiniRoundBets(tx, round)
+	if err != nil {
+		return nil, err
+	}
+	return CancelMiniRoundBets(tx, round.GameType, round.Date, round.Round, bets, action, reason, adminID)
+}
+
+// CancelMiniRoundBets cancels bets, stored under round of gameType on the game day date, and
+// refunds their stake inside tx. Bets no longer pending are left alone; the action is recorded
+// in the audit trail only when it cancelled a bet.
+func CancelMiniRoundBets(tx *gorm.DB, gameType, date string, round uint, bets []models.PowerballHistory, action, reason string, adminID *uint) (*models.MiniGameRoundAction, error) {
+	audit := models.MiniGameRoundAction{
+		GameType: gameType,
+		DrawDate: date,
+		Round:    round,
+		Action:   action,
+		AdminID:  adminID,
+		Reason:   reason,
+	}
+
+	for _, bet := range bets {
+		result := tx.Model(&models.PowerballHistory{}).
+			Where("id = ? AND status = ?", bet.ID, "pending").
+			Updates(map[string]interface{}{
+				"status": "cancelled",
+				"result": "cancelled",
+			})
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		if result.RowsAffected == 0 {
+			continue
+		}
+
+		var profile models.Profile
+		if err := tx.Where("user_id = ?", bet.UserID).First(&profile).Error; err != nil {
+			return nil, err
+		}
+
+		// Undo the stake and the wager it added
+		balanceAfter := profile.Balance + bet.Amount
+		wagerAfter := profile.Wager - bet.Amount
+		if wagerAfter < 0 {
+			wagerAfter = 0
+		}
+		if err := tx.Model(&profile).Updates(map[string]interface{}{
+			"balance": balanceAfter,
+			"wager":   wagerAfter,
+		}).Error; err != nil {
+			return nil, err
+		}
+
+		if err := tx.Create(&models.Transaction{
+			UserID:        bet.UserID,
+			Type:          "minigame_refund",
+			Amount:        bet.Amount,
+			BalanceBefore: profile.Balance,
+			BalanceAfter:  balanceAfter,
+			Explation:     fmt.Sprintf("%d", bet.ID),
+			Status:        "A",
+			ApprovedAt:    time.Now(),
+		}).Error; err != nil {
+			return nil, err
+		}
+
+		audit.Bets++
+		audit.Amount += bet.Amount
+	}
+
+	if audit.Bets == 0 {
+		return &audit, nil
+	}
+	if err := tx.Create(&audit).Error; err != nil {
+		return nil, err
+	}
+	return &audit, nil
+}
+
+// SettleMiniRound records draw and settles every pending bet placed during round against it.
+// Each bet is settled in its own transaction, so a round settled partly is finished by the next
+// pass against the draw stored by the first.
+func SettleMiniRound(db *gorm.DB, r minigame.Round, draw models.MiniGameDraw) {
+	round := r.Round
+
+	// Store the draw once, even when the round had no bets
+	if err := RecordMiniGameDraw(db, &draw); err != nil {
+		fmt.Printf("❌ Failed to record %s round %d draw: %v\n", r.GameType, round, err)
+		return
+	}
+	outcome := minigame.NewOutcome(draw)
+
+	// Settle all pending bets placed while this round was open
+	pendingBets, err := PendingMiniRoundBets(db, r)
+	if err != nil {
+		fmt.Printf("❌ Failed to load pending bets: %v\n", err)
+		return
+	}
+
+	for i := range pendingBets {
+		bet := &pendingBets[i]
+
+		// Begin transaction per bet to avoid partial updates
+		tx := db.Begin()
+		if _, err := settleMiniBet(tx, bet, draw, outcome); err != nil {
+			tx.Rollback()
+			fmt.Printf("❌ Failed to settle bet %d: %v\n", bet.ID, err)
+			continue
+		}
+		tx.Commit()
+	}
+}
+
+// settleMiniBet grades a pending bet against draw inside tx, pays a win and books its rolling,
+// points and statistics. It reports false for a bet no longer pending, which may have been
+// cancelled and refunded meanwhile.
+func settleMiniBet(tx *gorm.DB, bet *models.PowerballHistory, draw models.MiniGameDraw, outcome minigame.Outcome) (bool, error) {
+	// Grade against the rule table; a bet with an unknown pick is settled as lost
+	won, err := minigame.GradeBet(*bet, outcome)
+	if err != nil {
+		fmt.Printf("⚠️ Bet %d cannot be graded, settling as lost: %v\n", bet.ID, err)
+	}
+
+	// Link the bet to its draw and record the outcome
+	result := "lose"
+	if won {
+		result = "win"
+	}
+	updated := tx.Model(bet).Where("id = ? AND status = ?", bet.ID, "pending").Updates(map[string]interface{}{
+		"draw_id": draw.ID,
+		"status":  "done",
+		"result":  result,
+	})
+	if updated.Error != nil {
+		return false, updated.Error
+	}
+	if updated.RowsAffected == 0 {
+		return false, nil
+	}
+
+	payout := 0.0
+	if won {
+		// Credit winnings: payout = amount * odds
+		payout = bet.Amount * bet.Odds
+
+		var profile models.Profile
+		if err := tx.Where("user_id = ?", bet.UserID).First(&profile).Error; err != nil {
+			return false, err
+		}
+		balanceAfter := profile.Balance + payout
+		if err := tx.Model(&profile).Update("balance", balanceAfter).Error; err != nil {
+			return false, err
+		}
+
+		// Save transaction for the win
+		if err := tx.Create(&models.Transaction{
+			UserID:        bet.UserID,
+			Type:          "minigame_Win",
+			Amount:        payout,
+			BalanceBefore: profile.Balance,
+			BalanceAfter:  balanceAfter,
+			Explation:     fmt.Sprintf("%d", bet.ID),
+			Status:        "A",
+		}).Error; err != nil {
+			return false, err
+		}
+
+		fmt.Printf("✅ Transaction created for bet %d: payout=%.2f, balance %.2f -> %.2f\n",
+			bet.ID, payout, profile.Balance, balanceAfter)
+	}
+
+	// Rolling for the user and upline, points for a loss, betting statistics
+	if err := AccrueMiniBetSettlement(tx, bet, won, payout); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// SettleMiniRoundManually settles round with a result entered by an admin, replacing any draw
+// stored for it, and records the action in the audit trail. Bets the feed already settled are
+// regraded: their payout, rolling, points and statistics are reversed and they are settled
+// again against the entered draw, all in one transaction.
+func SettleMiniRoundManually(db *gorm.DB, round minigame.Round, draw minigame.HouseDraw, reason string, adminID uint) (*models.MiniGameRoundAction, error) {
+	details, _ := json.Marshal(draw)
+	action := models.MiniGameRoundAction{
+		GameType: round.GameType,
+		DrawDate: round.Date,
+		Round:    round.Round,
+		Action:   "result",
+		AdminID:  &adminID,
+		Reason:   reason,
+		Details:  string(details),
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		// Overwrite what the feed stored, the round is then graded against the entered draw
+		entered := draw.MiniGameDraw(round)
+		var stored models.MiniGameDraw
+		err := tx.Where("game_type = ? AND draw_date = ? AND round = ?", round.GameType, round.Date, round.Round).
+			First(&stored).Error
+		switch {
+		case err == nil:
+			entered.ID = stored.ID
+			entered.CreatedAt = stored.CreatedAt
+			if err := tx.Save(&entered).Error; err != nil {
+				return err
+			}
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			if err := RecordMiniGameDraw(tx, &entered); err != nil {
+				return err
+			}
+		default:
+			return err
+		}
+
+		var settled []models.PowerballHistory
+		if err := tx.Where("round = ? AND game_type = ? AND status = ? AND created_at >= ? AND created_at < ?",
+			round.Round, round.GameType, "done", round.OpenAt, round.DrawAt).Find(&settled).Error; err != nil {
+			return err
+		}
+		if len(settled) > 0 {
+			action.Action = "regrade"
+		}
+		for i := range settled {
+			if err := ReverseMiniBetSettlement(tx, &settled[i]); err != nil {
+				return fmt.Errorf("reversing bet %d: %w", settled[i].ID, err)
+			}
+		}
+
+		pending, err := PendingMiniRoundBets(tx, round)
+		if err != nil {
+			return err
+		}
+		outcome := minigame.NewOutcome(entered)
+		for i := range pending {
+			bet := &pending[i]
+			ok, err := settleMiniBet(tx, bet, entered, outcome)
+			if err != nil {
+				return fmt.Errorf("settling bet %d: %w", bet.ID, err)
+			}
+			if ok {
+				action.Bets++
+				action.Amount += bet.Amount
+			}
+		}
+
+		return tx.Create(&action).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &action, nil
+}
@@ -0,0 +1,148 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hotbrainy/go-betting/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// AwardPostPoints pays the level's posting points for a new post or comment inside tx.
+// It returns nil without paying when the user is excluded, the level pays nothing or
+// the daily limit is reached.
+func AwardPostPoints(tx *gorm.DB, user *models.User, postID uint, commentID *uint) (*models.PostPointAward, error) {
+	if user.PointsAwardedForThePost == "2" {
+		return nil, nil
+	}
+
+	var profile models.Profile
+	if err := tx.Where("user_id = ?", user.ID).First(&profile).Error; err != nil {
+		return nil, err
+	}
+
+	var level models.Level
+	if err := tx.Where("level_number = ?", profile.Level).First(&level).Error; err != nil {
+		// No level settings means no posting points
+		return nil, nil
+	}
+	if level.PointsAwardedWhenWritingAPost <= 0 {
+		return nil, nil
+	}
+
+	now := time.Now()
+	today := now.Format("2006-01-02")
+
+	// Clawed back awards still count, so deleting spam does not free up the limit
+	if level.DailyLimitOnNumberOfPostingPoints > 0 {
+		var awardedToday int64
+		if err := tx.Model(&models.PostPointAward{}).
+			Where("user_id = ? AND award_date = ?", user.ID, today).
+			Count(&awardedToday).Error; err != nil {
+			return nil, err
+		}
+		if awardedToday >= int64(level.DailyLimitOnNumberOfPostingPoints) {
+			return nil, nil
+		}
+	}
+
+	amount := level.PointsAwardedWhenWritingAPost
+	pointAfter := profile.Point + int32(amount)
+
+	if err := tx.Model(&profile).Update("point", pointAfter).Error; err != nil {
+		return nil, err
+	}
+
+	explanation := fmt.Sprintf("Points for post #%d", postID)
+	if commentID != nil {
+		explanation = fmt.Sprintf("Points for comment #%d on post #%d", *commentID, postID)
+	}
+
+	transaction := models.Transaction{
+		UserID:        user.ID,
+		Type:          "postPoint",
+		Amount:        amount,
+		BalanceBefore: profile.Balance,
+		BalanceAfter:  profile.Balance,
+		PointBefore:   float64(profile.Point),
+		PointAfter:    float64(pointAfter),
+		Explation:     explanation,
+		Status:        "A",
+		ApprovedAt:    now,
+	}
+	if err := tx.Create(&transaction).Error; err != nil {
+		return nil, err
+	}
+
+	award := models.PostPointAward{
+		UserID:        user.ID,
+		PostID:        postID,
+		CommentID:     commentID,
+		AwardDate:     today,
+		Amount:        amount,
+		TransactionID: transaction.ID,
+	}
+	if err := tx.Create(&award).Error; err != nil {
+		return nil, err
+	}
+
+	return &award, nil
+}
+
+// ClawbackPostPoints takes back the points paid for writing a post inside tx.
+// Points are only taken down to zero; the amount actually recovered is returned.
+func ClawbackPostPoints(tx *gorm.DB, postID uint) (float64, error) {
+	var awards []models.PostPointAward
+	if err := tx.Where("post_id = ? AND comment_id IS NULL AND clawed_back_at IS NULL", postID).Find(&awards).Error; err != nil {
+		return 0, err
+	}
+
+	recovered := 0.0
+	for _, award := range awards {
+		var profile models.Profile
+		if err := tx.Where("user_id = ?", award.UserID).First(&profile).Error; err != nil {
+			return recovered, err
+		}
+
+		amount := award.Amount
+		if float64(profile.Point) < amount {
+			amount = float64(profile.Point)
+		}
+		if amount < 0 {
+			amount = 0
+		}
+		pointAfter := profile.Point - int32(amount)
+
+		if err := tx.Model(&profile).Update("point", pointAfter).Error; err != nil {
+			return recovered, err
+		}
+
+		now := time.Now()
+		transaction := models.Transaction{
+			UserID:        award.UserID,
+			Type:          "postPointClawback",
+			Amount:        amount,
+			BalanceBefore: profile.Balance,
+			BalanceAfter:  profile.Balance,
+			PointBefore:   float64(profile.Point),
+			PointAfter:    float64(pointAfter),
+			Explation:     fmt.Sprintf("Points reclaimed for spam post #%d", postID),
+			Status:        "A",
+			ApprovedAt:    now,
+		}
+		if err := tx.Create(&transaction).Error; err != nil {
+			return recovered, err
+		}
+
+		if err := tx.Model(&award).Updates(map[string]interface{}{
+			"clawback_transaction_id": transaction.ID,
+			"clawed_back_at":          now,
+		}).Error; err != nil {
+			return recovered, err
+		}
+
+		recovered += amount
+	}
+
+	return recovered, nil
+}
@@ -0,0 +1,177 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hotbrainy/go-betting/backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrRollingInsufficient = errors.New("insufficient rolling for conversion")
+	ErrRollingNotPending   = errors.New("transaction is not a pending rolling conversion")
+)
+
+// RollingConversionLimitError reports which level limit a conversion request breaks
+type RollingConversionLimitError struct {
+	Message string
+}
+
+func (e *RollingConversionLimitError) Error() string {
+	return e.Message
+}
+
+// CheckRollingConversionLimits validates a rolling conversion request against the user's
+// balance and level limits. Pending and approved conversions made today both count. The profile
+// is locked, so that inside tx concurrent requests of the user are checked one after the other.
+func CheckRollingConversionLimits(tx *gorm.DB, userID uint, amount float64) error {
+	var profile models.Profile
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("user_id = ?", userID).First(&profile).Error; err != nil {
+		return err
+	}
+
+	if profile.Roll < amount {
+		return ErrRollingInsufficient
+	}
+
+	var level models.Level
+	if err := tx.Where("level_number = ?", profile.Level).First(&level).Error; err != nil {
+		// No level settings means no limits
+		return nil
+	}
+
+	if level.RollingCoversionMinimumAmount > 0 && amount < level.RollingCoversionMinimumAmount {
+		return &RollingConversionLimitError{
+			Message: fmt.Sprintf("The minimum rolling conversion amount is %.0f", level.RollingCoversionMinimumAmount),
+		}
+	}
+
+	now := time.Now()
+	startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	var today struct {
+		Count int64
+		Total float64
+	}
+	if err := tx.Model(&models.Transaction{}).
+		Select("COUNT(*) AS count, COALESCE(SUM(amount), 0) AS total").
+		Where("user_id = ? AND type = ? AND status IN ? AND created_at >= ?", userID, "rollingExchange", []string{"pending", "A"}, startOfDay).
+		Scan(&today).Error; err != nil {
+		return err
+	}
+
+	if level.RollingCoversionLimitPerDay > 0 && today.Count >= int64(level.RollingCoversionLimitPerDay) {
+		return &RollingConversionLimitError{
+			Message: fmt.Sprintf("Rolling can be converted at most %d times per day", level.RollingCoversionLimitPerDay),
+		}
+	}
+
+	if level.RollingCoversion1DayAmountLimit > 0 && today.Total+amount > float64(level.RollingCoversion1DayAmountLimit) {
+		return &RollingConversionLimitError{
+			Message: fmt.Sprintf("The daily rolling conversion limit is %d (%.0f remaining)",
+				level.RollingCoversion1DayAmountLimit, float64(level.RollingCoversion1DayAmountLimit)-today.Total),
+		}
+	}
+
+	return nil
+}
+
+// RequestRollingConversion records the user's request to convert amount of rolling to balance,
+// checked against their rolling and level limits in the same transaction. With autoApprove the
+// conversion is completed right away, otherwise it waits for an admin.
+func RequestRollingConversion(db *gorm.DB, userID uint, amount float64, explanation string, autoApprove bool) (*models.Transaction, error) {
+	var transaction models.Transaction
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := CheckRollingConversionLimits(tx, userID, amount); err != nil {
+			return err
+		}
+
+		var profile models.Profile
+		if err := tx.Where("user_id = ?", userID).First(&profile).Error; err != nil {
+			return err
+		}
+
+		// For rolling exchange, PointBefore/PointAfter represent rolling before/after
+		transaction = models.Transaction{
+			UserID:        userID,
+			Amount:        amount,
+			Type:          "rollingExchange",
+			Explation:     explanation,
+			BalanceBefore: profile.Balance,
+			BalanceAfter:  profile.Balance + amount,
+			PointBefore:   profile.Roll,
+			PointAfter:    profile.Roll - amount,
+			Status:        "pending",
+		}
+		if err := tx.Create(&transaction).Error; err != nil {
+			return err
+		}
+
+		if !autoApprove {
+			return nil
+		}
+		if err := CompleteRollingConversion(tx, &transaction, amount); err != nil {
+			return err
+		}
+		return tx.First(&transaction, transaction.ID).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &transaction, nil
+}
+
+// CompleteRollingConversion moves amount from the user's rolling to balance and approves the
+// pending rollingExchange transaction inside tx
+func CompleteRollingConversion(tx *gorm.DB, transaction *models.Transaction, amount float64) error {
+	if transaction.Type != "rollingExchange" || transaction.Status != "pending" {
+		return ErrRollingNotPending
+	}
+
+	var profile models.Profile
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("user_id = ?", transaction.UserID).First(&profile).Error; err != nil {
+		return err
+	}
+
+	if profile.Roll < amount {
+		return ErrRollingInsufficient
+	}
+
+	rollBefore := profile.Roll
+	balanceBefore := profile.Balance
+	newRoll := profile.Roll - amount
+	newBalance := profile.Balance + amount
+
+	// Map so that a roll of zero is written as well
+	if err := tx.Model(&profile).Updates(map[string]interface{}{
+		"roll":    newRoll,
+		"balance": newBalance,
+	}).Error; err != nil {
+		return err
+	}
+
+	// For rolling exchange, PointBefore/PointAfter represent rolling before/after. The status
+	// flips only from pending, so a conversion approved twice at once is credited once.
+	result := tx.Model(transaction).Where("status = ?", "pending").Updates(map[string]interface{}{
+		"status":         "A",
+		"amount":         amount,
+		"balance_before": balanceBefore,
+		"balance_after":  newBalance,
+		"point_before":   rollBefore,
+		"point_after":    newRoll,
+		"explation":      "Rolling conversion to balance",
+		"approved_at":    time.Now(),
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrRollingNotPending
+	}
+	return nil
+}
@@ -0,0 +1,419 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hotbrainy/go-betting/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrRouletteInactive    = errors.New("roulette is currently disabled")
+	ErrRouletteNoSpins     = errors.New("no roulette spins available")
+	ErrRouletteNoSegments  = errors.New("roulette has no active segments")
+	ErrRouletteBusy        = errors.New("another spin is in progress, please retry")
+	ErrRouletteNotRevealed = errors.New("the server seed for this spin has not been revealed yet")
+)
+
+// GetRouletteConfig returns the stored roulette config or the defaults if none exists
+func GetRouletteConfig(db *gorm.DB) models.RouletteConfig {
+	var config models.RouletteConfig
+	if err := db.First(&config).Error; err != nil {
+		config = models.RouletteConfig{
+			IsActive:      true,
+			DailySpins:    1,
+			SpinValidDays: 7,
+		}
+	}
+	return config
+}
+
+// GrantRouletteSpins grants spins to a user. Each (source, sourceRef) pair is granted at most once.
+func GrantRouletteSpins(tx *gorm.DB, userID uint, source, sourceRef string, spins int, expiresAt *time.Time) error {
+	if spins <= 0 {
+		return nil
+	}
+
+	entitlement := models.RouletteEntitlement{
+		UserID:    userID,
+		Source:    source,
+		SourceRef: sourceRef,
+	}
+	return tx.Where(&entitlement).
+		Attrs(models.RouletteEntitlement{Spins: spins, ExpiresAt: expiresAt}).
+		FirstOrCreate(&entitlement).Error
+}
+
+// GrantDepositRouletteSpins grants the spins earned by an approved deposit
+func GrantDepositRouletteSpins(tx *gorm.DB, userID, transactionID uint, amount float64) error {
+	config := GetRouletteConfig(tx)
+	if !config.IsActive || config.DepositSpinUnit <= 0 {
+		return nil
+	}
+
+	spins := int(amount / config.DepositSpinUnit)
+	if config.MaxDepositSpins > 0 && spins > config.MaxDepositSpins {
+		spins = config.MaxDepositSpins
+	}
+
+	return GrantRouletteSpins(tx, userID, "deposit", strconv.FormatUint(uint64(transactionID), 10), spins, rouletteSpinExpiry(config))
+}
+
+// GrantAttendanceRouletteSpins grants the spins earned by an attendance check-in
+func GrantAttendanceRouletteSpins(tx *gorm.DB, userID, attendanceID uint) error {
+	config := GetRouletteConfig(tx)
+	if !config.IsActive {
+		return nil
+	}
+
+	return GrantRouletteSpins(tx, userID, "attendance", strconv.FormatUint(uint64(attendanceID), 10), config.AttendanceSpins, rouletteSpinExpiry(config))
+}
+
+// ensureDailyRouletteSpins grants today's free spins if they have not been granted yet
+func ensureDailyRouletteSpins(tx *gorm.DB, userID uint, config models.RouletteConfig) error {
+	now := time.Now()
+	endOfDay := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, now.Location())
+
+	return GrantRouletteSpins(tx, userID, "daily", now.Format("2006-01-02"), config.DailySpins, &endOfDay)
+}
+
+func rouletteSpinExpiry(config models.RouletteConfig) *time.Time {
+	if config.SpinValidDays <= 0 {
+		return nil
+	}
+	expiresAt := time.Now().AddDate(0, 0, config.SpinValidDays)
+	return &expiresAt
+}
+
+// availableEntitlements returns the user's unexpired entitlements with spins left, soonest expiry first
+func availableEntitlements(tx *gorm.DB, userID uint) *gorm.DB {
+	return tx.Model(&models.RouletteEntitlement{}).
+		Where("user_id = ? AND used_spins < spins AND (expires_at IS NULL OR expires_at > ?)", userID, time.Now()).
+		Order("expires_at ASC NULLS LAST, id ASC")
+}
+
+// AvailableRouletteSpins returns how many spins the user can use now, granting today's free spins first
+func AvailableRouletteSpins(tx *gorm.DB, userID uint) (int64, error) {
+	config := GetRouletteConfig(tx)
+	if config.IsActive {
+		if err := ensureDailyRouletteSpins(tx, userID, config); err != nil {
+			return 0, err
+		}
+	}
+
+	var spins int64
+	err := availableEntitlements(tx, userID).
+		Select("COALESCE(SUM(spins - used_spins), 0)").
+		Scan(&spins).Error
+	return spins, err
+}
+
+// ActiveRouletteSeed returns the user's active seed pair, creating one if needed
+func ActiveRouletteSeed(tx *gorm.DB, userID uint) (*models.RouletteSeed, error) {
+	var seed models.RouletteSeed
+	err := tx.Where("user_id = ? AND is_active = ?", userID, true).First(&seed).Error
+	if err == nil {
+		return &seed, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	return newRouletteSeed(tx, userID, "")
+}
+
+// RotateRouletteSeed reveals the user's active server seed and starts a new seed pair.
+// An empty clientSeed generates a random one.
+func RotateRouletteSeed(tx *gorm.DB, userID uint, clientSeed string) (revealed *models.RouletteSeed, next *models.RouletteSeed, err error) {
+	revealed, err = ActiveRouletteSeed(tx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	revealed.IsActive = false
+	revealed.RevealedAt = &now
+	if err := tx.Model(revealed).Updates(map[string]interface{}{
+		"is_active":   false,
+		"revealed_at": now,
+	}).Error; err != nil {
+		return nil, nil, err
+	}
+
+	next, err = newRouletteSeed(tx, userID, clientSeed)
+	if err != nil {
+		return nil, nil, err
+	}
+	return revealed, next, nil
+}
+
+func newRouletteSeed(tx *gorm.DB, userID uint, clientSeed string) (*models.RouletteSeed, error) {
+	serverSeed, err := randomHex(32)
+	if err != nil {
+		return nil, err
+	}
+	if clientSeed == "" {
+		if clientSeed, err = randomHex(8); err != nil {
+			return nil, err
+		}
+	}
+
+	hash := sha256.Sum256([]byte(serverSeed))
+	seed := models.RouletteSeed{
+		UserID:         userID,
+		ServerSeed:     serverSeed,
+		ServerSeedHash: hex.EncodeToString(hash[:]),
+		ClientSeed:     clientSeed,
+		IsActive:       true,
+	}
+	if err := tx.Create(&seed).Error; err != nil {
+		return nil, err
+	}
+	return &seed, nil
+}
+
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// RouletteRoll derives a number in [0, 1) from HMAC-SHA256(serverSeed, "clientSeed:nonce")
+func RouletteRoll(serverSeed, clientSeed string, nonce uint) float64 {
+	mac := hmac.New(sha256.New, []byte(serverSeed))
+	mac.Write([]byte(fmt.Sprintf("%s:%d", clientSeed, nonce)))
+	sum := mac.Sum(nil)
+
+	// Top 53 bits give a uniformly distributed float64
+	return float64(binary.BigEndian.Uint64(sum[:8])>>11) / (1 << 53)
+}
+
+// PickRouletteSegment returns the index of the segment the roll lands on, or -1 if there is no weight
+func PickRouletteSegment(segments []models.RouletteSegmentWeight, roll float64) int {
+	total := 0
+	for _, segment := range segments {
+		total += segment.Weight
+	}
+	if total <= 0 {
+		return -1
+	}
+
+	target := roll * float64(total)
+	cumulative := 0.0
+	for i, segment := range segments {
+		cumulative += float64(segment.Weight)
+		if target < cumulative {
+			return i
+		}
+	}
+	return len(segments) - 1
+}
+
+// VerifyRouletteSpin recomputes a spin from its seed and returns the roll and segment it should have landed on
+func VerifyRouletteSpin(spin *models.RouletteSpin, seed *models.RouletteSeed) (float64, uint, error) {
+	if seed.RevealedAt == nil {
+		return 0, 0, ErrRouletteNotRevealed
+	}
+
+	roll := RouletteRoll(seed.ServerSeed, seed.ClientSeed, spin.Nonce)
+	index := PickRouletteSegment(spin.Segments, roll)
+	if index < 0 {
+		return roll, 0, ErrRouletteNoSegments
+	}
+	return roll, spin.Segments[index].ID, nil
+}
+
+// SpinRoulette uses one of the user's spins, draws a segment and pays its prize inside tx
+func SpinRoulette(tx *gorm.DB, userID uint) (*models.RouletteSpin, error) {
+	config := GetRouletteConfig(tx)
+	if !config.IsActive {
+		return nil, ErrRouletteInactive
+	}
+
+	if err := ensureDailyRouletteSpins(tx, userID, config); err != nil {
+		return nil, err
+	}
+
+	// Use a spin from the entitlement that expires first
+	var entitlement models.RouletteEntitlement
+	if err := availableEntitlements(tx, userID).First(&entitlement).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRouletteNoSpins
+		}
+		return nil, err
+	}
+	result := tx.Model(&models.RouletteEntitlement{}).
+		Where("id = ? AND used_spins < spins", entitlement.ID).
+		Update("used_spins", gorm.Expr("used_spins + 1"))
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, ErrRouletteNoSpins
+	}
+
+	var segments []models.RouletteSegment
+	if err := tx.Where("is_active = ? AND weight > 0", true).Order("sort_order ASC, id ASC").Find(&segments).Error; err != nil {
+		return nil, err
+	}
+	weights := make([]models.RouletteSegmentWeight, len(segments))
+	for i, segment := range segments {
+		weights[i] = models.RouletteSegmentWeight{ID: segment.ID, Weight: segment.Weight}
+	}
+
+	seed, err := ActiveRouletteSeed(tx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Claim the nonce; a concurrent spin on the same seed makes this a no-op
+	nonce := seed.Nonce
+	result = tx.Model(&models.RouletteSeed{}).
+		Where("id = ? AND nonce = ?", seed.ID, nonce).
+		Update("nonce", nonce+1)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, ErrRouletteBusy
+	}
+
+	roll := RouletteRoll(seed.ServerSeed, seed.ClientSeed, nonce)
+	index := PickRouletteSegment(weights, roll)
+	if index < 0 {
+		return nil, ErrRouletteNoSegments
+	}
+	segment := segments[index]
+
+	spin := models.RouletteSpin{
+		UserID:        userID,
+		EntitlementID: entitlement.ID,
+		SeedID:        seed.ID,
+		Nonce:         nonce,
+		Segments:      weights,
+		Roll:          roll,
+		SegmentID:     segment.ID,
+		Label:         segment.Label,
+		PrizeType:     segment.PrizeType,
+		Amount:        segment.Amount,
+		Status:        "paid",
+	}
+	if err := tx.Create(&spin).Error; err != nil {
+		return nil, err
+	}
+
+	if err := payRoulettePrize(tx, &spin, &segment); err != nil {
+		return nil, err
+	}
+
+	return &spin, nil
+}
+
+// payRoulettePrize credits the segment's prize and links the resulting ledger entry to the spin.
+// Coupons the campaign can no longer issue leave the spin "prize_failed" for admins instead of
+// failing it, so the spin and its nonce are kept.
+func payRoulettePrize(tx *gorm.DB, spin *models.RouletteSpin, segment *models.RouletteSegment) error {
+	if segment.Amount <= 0 {
+		return nil
+	}
+
+	switch segment.PrizeType {
+	case "point", "cash":
+		var profile models.Profile
+		if err := tx.Where("user_id = ?", spin.UserID).First(&profile).Error; err != nil {
+			return err
+		}
+
+		transaction := models.Transaction{
+			UserID:        spin.UserID,
+			Amount:        segment.Amount,
+			BalanceBefore: profile.Balance,
+			BalanceAfter:  profile.Balance,
+			PointBefore:   float64(profile.Point),
+			PointAfter:    float64(profile.Point),
+			Explation:     fmt.Sprintf("Roulette spin #%d: %s", spin.ID, segment.Label),
+			Status:        "A",
+			ApprovedAt:    time.Now(),
+		}
+
+		if segment.PrizeType == "point" {
+			transaction.Type = "roulettePoint"
+			transaction.PointAfter = float64(profile.Point) + segment.Amount
+			if err := tx.Model(&profile).Update("point", profile.Point+int32(segment.Amount)).Error; err != nil {
+				return err
+			}
+		} else {
+			transaction.Type = "rouletteCash"
+			transaction.BalanceAfter = profile.Balance + segment.Amount
+			if err := tx.Model(&profile).Update("balance", transaction.BalanceAfter).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Create(&transaction).Error; err != nil {
+			return err
+		}
+		spin.TransactionID = &transaction.ID
+	case "coupon":
+		if err := tx.SavePoint("roulette_coupon").Error; err != nil {
+			return err
+		}
+		if err := issueRouletteCoupons(tx, spin, segment); err != nil {
+			if err := tx.RollbackTo("roulette_coupon").Error; err != nil {
+				return err
+			}
+			spin.UserCouponID = nil
+			spin.Status = "prize_failed"
+			spin.PrizeError = err.Error()
+
+			title := "Roulette Prize Failed"
+			message := fmt.Sprintf("Roulette spin #%d of user ID %d won %s but the coupon could not be issued: %v",
+				spin.ID, spin.UserID, segment.Label, err)
+			if _, err := CreateAlert(tx, "roulettePrize", title, message, spin.ID, "/admin/roulette/spins?status=prize_failed"); err != nil {
+				return err
+			}
+		}
+	default:
+		return nil
+	}
+
+	return tx.Model(spin).Updates(map[string]interface{}{
+		"transaction_id": spin.TransactionID,
+		"user_coupon_id": spin.UserCouponID,
+		"status":         spin.Status,
+		"prize_error":    spin.PrizeError,
+	}).Error
+}
+
+// issueRouletteCoupons issues the segment's coupons, Amount being their number, and links the
+// first one to the spin
+func issueRouletteCoupons(tx *gorm.DB, spin *models.RouletteSpin, segment *models.RouletteSegment) error {
+	if segment.CouponCampaignID == nil {
+		return fmt.Errorf("roulette segment %d has no coupon campaign", segment.ID)
+	}
+	var campaign models.CouponCampaign
+	if err := tx.First(&campaign, *segment.CouponCampaignID).Error; err != nil {
+		return err
+	}
+
+	for i := 0; i < int(segment.Amount); i++ {
+		coupon, err := IssueCoupon(tx, &campaign, spin.UserID, "roulette", nil)
+		if err != nil {
+			return err
+		}
+		if spin.UserCouponID == nil {
+			spin.UserCouponID = &coupon.ID
+		}
+	}
+	return nil
+}